@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"dnd-char-generator/internal/dice"
 	"dnd-char-generator/internal/domain"
 	"fmt"
 	"sort"
@@ -27,10 +28,46 @@ type CreateCharacterRequest struct {
 	Name             string
 	Race             string
 	Class            string
+	Subclass         string
 	Background       string
 	Level            int
 	ScoreAssignments map[string]int
 	InitialSkills    []string
+
+	// AbilityScoreMethod is "standard" (the default), "point-buy" or
+	// "rolled". Rolled scores are checked against the rolls for Seed.
+	AbilityScoreMethod string
+	Seed               int64
+
+	// StartingGear is "equipment" for the class's starting equipment,
+	// "wealth" for its starting gold instead, or empty for neither. The gold
+	// is the class average unless RollWealth is set.
+	StartingGear string
+	RollWealth   bool
+
+	// Improvements are the choices for the class's Ability Score
+	// Improvements up to Level, in level order.
+	Improvements []ImprovementChoice
+}
+
+// ImprovementChoice is the choice made at an Ability Score Improvement: either
+// ability score increases or a feat, with the ability for feats that offer one.
+type ImprovementChoice struct {
+	AbilityIncreases map[string]int
+	Feat             string
+	FeatAbility      string
+}
+
+// LevelUpRequest describes a single level gained in a class. At Ability Score
+// Improvement levels either AbilityIncreases (+2 to one ability or +1 to two)
+// or Feat must be set; FeatAbility picks the ability for feats offering a choice.
+type LevelUpRequest struct {
+	Name             string
+	Class            string
+	Subclass         string
+	AbilityIncreases map[string]int
+	Feat             string
+	FeatAbility      string
 }
 
 type CharacterService struct {
@@ -40,6 +77,14 @@ type CharacterService struct {
 	AllWeapons map[string]domain.Weapon
 	AllArmors  map[string]domain.Armor
 	AllShields map[string]domain.Shield
+	AllGear    map[string]domain.Equipment
+
+	ClassFeatures map[string][]domain.ClassFeature
+	MagicItems    map[string]domain.MagicItem
+
+	Rules domain.Rules
+
+	Roller *dice.Roller
 }
 
 func NewCharacterService(
@@ -49,6 +94,8 @@ func NewCharacterService(
 	weapons map[string]domain.Weapon,
 	armors map[string]domain.Armor,
 	shields map[string]domain.Shield,
+	gear map[string]domain.Equipment,
+	classFeatures map[string][]domain.ClassFeature,
 ) *CharacterService {
 	return &CharacterService{
 		Repo:       repo,
@@ -57,10 +104,24 @@ func NewCharacterService(
 		AllWeapons: weapons,
 		AllArmors:  armors,
 		AllShields: shields,
+		AllGear:    gear,
+
+		ClassFeatures: classFeatures,
+
+		Roller: dice.NewRandomRoller(),
 	}
 }
 
 func (s *CharacterService) CreateCharacter(ctx context.Context, req CreateCharacterRequest) (*domain.Character, error) {
+	method, err := domain.NewAbilityScoreMethod(req.AbilityScoreMethod, req.Seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := method.Validate(req.ScoreAssignments); err != nil {
+		return nil, fmt.Errorf("invalid %s ability scores: %w", method.Name(), err)
+	}
+
 	newChar, err := domain.NewCharacter(
 		req.Name,
 		req.Race,
@@ -72,7 +133,27 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req CreateCharac
 		return nil, fmt.Errorf("domain creation failed: %w", err)
 	}
 
+	newChar.AbilityScoreMethod = method.Name()
+	if _, rolled := method.(domain.RolledMethod); rolled {
+		newChar.AbilityScoreSeed = req.Seed
+	}
+
 	newChar.Level = req.Level
+	newChar.ClassLevels[strings.ToLower(req.Class)] = req.Level
+
+	if req.Subclass != "" {
+		if err := newChar.SetSubclass(req.Class, req.Subclass); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := newChar.ValidateSubclasses(); err != nil {
+		return nil, err
+	}
+
+	if err := applyStartingImprovements(newChar, req); err != nil {
+		return nil, err
+	}
 
 	bgName := strings.ToLower(req.Background)
 	className := strings.ToLower(req.Class)
@@ -103,6 +184,13 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req CreateCharac
 	newChar.CalculateCombatStats()
 	newChar.CalculateSpellStats()
 	newChar.CalculateMaxSpellSlots()
+	newChar.UpdateClassFeatures(s.ClassFeatures)
+
+	if err := s.applyStartingGear(newChar, req); err != nil {
+		return nil, err
+	}
+
+	newChar.CalculateSpeed(s.Rules.VariantEncumbrance)
 
 	if err := s.Repo.Save(ctx, newChar); err != nil {
 		return nil, fmt.Errorf("failed to save new character: %w", err)
@@ -111,20 +199,67 @@ func (s *CharacterService) CreateCharacter(ctx context.Context, req CreateCharac
 	return newChar, nil
 }
 
+// applyStartingImprovements applies the ASI choices for a character created
+// above level 1, one for each ASI level of the class up to its level.
+func applyStartingImprovements(char *domain.Character, req CreateCharacterRequest) error {
+	className := strings.ToLower(req.Class)
+	pending := domain.PendingASILevels(className, 0, req.Level)
+
+	if len(req.Improvements) > len(pending) {
+		return fmt.Errorf("%s level %d grants %d Ability Score Improvements, got %d choices",
+			className, req.Level, len(pending), len(req.Improvements))
+	}
+
+	for i, classLevel := range pending {
+		if i >= len(req.Improvements) {
+			return fmt.Errorf("%s level %d grants an Ability Score Improvement: choose ability score increases or a feat",
+				className, classLevel)
+		}
+
+		choice := req.Improvements[i]
+		if err := char.ApplyAbilityScoreImprovement(className, classLevel, choice.AbilityIncreases, choice.Feat, choice.FeatAbility); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *CharacterService) GetCharacter(ctx context.Context, name string) (*domain.Character, error) {
 	char, err := s.Repo.FindByID(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(char.SavingThrowProficiencies) == 0 {
+		char.SetClassSavingThrows()
+	}
+	char.UpdateEquipmentProficiencies()
+
+	s.refreshSpellData(char)
+	s.refreshEquipment(char)
+
 	char.CalculateMaxHitPoints()
 	char.UpdateProficiencyBonus(char.Level)
 	char.CalculateCombatStats()
 	char.CalculateSpellStats()
+	char.UpdateClassFeatures(s.ClassFeatures)
+	char.CalculateSpeed(s.Rules.VariantEncumbrance)
 
 	return char, nil
 }
 
+// ExplainStat breaks a derived statistic of the character down into the
+// sources that add up to it.
+func (s *CharacterService) ExplainStat(ctx context.Context, name, stat string) ([]domain.Breakdown, error) {
+	char, err := s.GetCharacter(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return char.Explain(stat)
+}
+
 func (s *CharacterService) DeleteCharacter(ctx context.Context, name string) error {
 	return s.Repo.Delete(ctx, name)
 }
@@ -141,6 +276,11 @@ func (s *CharacterService) enrichArmor(ctx context.Context, a *domain.Armor, wg
 
 func (s *CharacterService) enrichSpell(ctx context.Context, sp *domain.Spell, wg *sync.WaitGroup) {
 	defer wg.Done()
+
+	// The API is only a fallback for spells the compendium didn't cover
+	if sp.IsComplete() {
+		return
+	}
 	s.ApiClient.EnrichSpell(ctx, sp)
 }
 
@@ -157,20 +297,86 @@ func (s *CharacterService) ListCharacters(ctx context.Context) ([]*domain.Charac
 	return chars, nil
 }
 
-func (s *CharacterService) UpdateCharacterLevel(ctx context.Context, name string, level int) error {
+// UpdateCharacterLevel sets the character's total level. The subclass is
+// required when the starting class reaches its subclass level. Raising the
+// level past an Ability Score Improvement has to go through LevelUp so the
+// choice can be made.
+func (s *CharacterService) UpdateCharacterLevel(ctx context.Context, name string, level int, subclass string) error {
 	char, err := s.Repo.FindByID(ctx, name)
 	if err != nil {
 		return err
 	}
 
-	char.Level = level
+	primary := strings.ToLower(char.Class)
+	currentLevel := char.ClassLevelBreakdown()[primary]
+	if pending := domain.PendingASILevels(primary, currentLevel, currentLevel+level-char.Level); len(pending) > 0 {
+		return fmt.Errorf("%s level %d grants an Ability Score Improvement: use level-up to choose it", primary, pending[0])
+	}
 
-	char.UpdateProficiencyBonus(char.Level)
+	if err := char.SetTotalLevel(level); err != nil {
+		return err
+	}
+
+	if subclass != "" {
+		if err := char.SetSubclass(char.Class, subclass); err != nil {
+			return err
+		}
+	}
+
+	if err := char.ValidateSubclasses(); err != nil {
+		return err
+	}
 
+	char.UpdateEquipmentProficiencies()
 	char.CalculateMaxHitPoints()
 	char.CalculateMaxSpellSlots()
 	char.CalculateSpellStats()
 	char.CalculateCombatStats()
+	char.UpdateClassFeatures(s.ClassFeatures)
+
+	return s.Repo.Save(ctx, char)
+}
+
+// LevelUp gains a level in the given class, multiclassing into it if the
+// character doesn't have it yet. The subclass is required when the class
+// reaches its subclass level, and an ASI or feat at ASI levels.
+func (s *CharacterService) LevelUp(ctx context.Context, req LevelUpRequest) error {
+	char, err := s.Repo.FindByID(ctx, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := char.AddClassLevel(req.Class); err != nil {
+		return err
+	}
+
+	className := strings.ToLower(req.Class)
+	classLevel := char.ClassLevelBreakdown()[className]
+
+	if domain.IsASILevel(className, classLevel) {
+		if err := char.ApplyAbilityScoreImprovement(className, classLevel, req.AbilityIncreases, req.Feat, req.FeatAbility); err != nil {
+			return err
+		}
+	} else if len(req.AbilityIncreases) > 0 || req.Feat != "" {
+		return fmt.Errorf("%s level %d does not grant an Ability Score Improvement", className, classLevel)
+	}
+
+	if req.Subclass != "" {
+		if err := char.SetSubclass(req.Class, req.Subclass); err != nil {
+			return err
+		}
+	}
+
+	if err := char.ValidateSubclasses(); err != nil {
+		return err
+	}
+
+	char.UpdateEquipmentProficiencies()
+	char.CalculateMaxHitPoints()
+	char.CalculateSpellStats()
+	char.CalculateMaxSpellSlots()
+	char.CalculateCombatStats()
+	char.UpdateClassFeatures(s.ClassFeatures)
 
 	return s.Repo.Save(ctx, char)
 }
@@ -182,10 +388,13 @@ func (s *CharacterService) filterSpellsByLevel(level int) []domain.Spell {
 	return nil
 }
 
-func (s *CharacterService) EquipItem(ctx context.Context, name, itemName, itemType, slot string) error {
+// EquipItem equips a weapon, armor or shield from the SRD data. Equipping an
+// item the character isn't proficient with returns a warning, or an error
+// when the rules refuse it.
+func (s *CharacterService) EquipItem(ctx context.Context, name, itemName, itemType, slot string) ([]string, error) {
 	char, err := s.Repo.FindByID(ctx, name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rateLimiter := time.NewTicker(time.Millisecond * 100)
@@ -193,9 +402,21 @@ func (s *CharacterService) EquipItem(ctx context.Context, name, itemName, itemTy
 
 	var wg sync.WaitGroup
 
+	itemName = strings.ToLower(strings.TrimSpace(itemName))
+	fromInventory := s.Rules.EquipFromInventory
+
 	switch strings.ToLower(itemType) {
 	case "weapon":
-		if w, ok := s.AllWeapons[itemName]; ok {
+		if w, ok := s.findWeapon(char, itemName); ok {
+			// A two-handed weapon frees the off hand, back into the inventory
+			if fromInventory && slot == "main hand" && w.TwoHanded {
+				for _, freed := range []string{"off hand", "shield"} {
+					if err := s.returnToInventory(char, freed); err != nil {
+						return nil, err
+					}
+				}
+			}
+
 			var equippedWeapon *domain.Weapon
 			if slot == "main hand" {
 				equippedWeapon = &char.EquippedWeaponMainHand
@@ -204,114 +425,131 @@ func (s *CharacterService) EquipItem(ctx context.Context, name, itemName, itemTy
 			}
 
 			if err := char.EquipWeaponSlot(w, slot); err != nil {
-				return err
+				return nil, err
 			}
 
 			if equippedWeapon != nil {
-				wg.Add(1)
-				<-rateLimiter.C
-				go s.enrichWeapon(ctx, equippedWeapon, &wg)
-				wg.Wait()
+				// The API only fills in weapons missing from the local catalogue
+				if equippedWeapon.Damage == "" {
+					wg.Add(1)
+					<-rateLimiter.C
+					go s.enrichWeapon(ctx, equippedWeapon, &wg)
+					wg.Wait()
+				}
 
 				if slot == "off hand" && char.EquippedWeaponMainHand.TwoHanded {
 					char.EquippedWeaponOffHand = domain.Weapon{}
-					return fmt.Errorf("cannot equip to off hand: main hand weapon '%s' is two-handed", char.EquippedWeaponMainHand.Name)
+					return nil, fmt.Errorf("cannot equip to off hand: main hand weapon '%s' is two-handed", char.EquippedWeaponMainHand.Name)
 				}
 
 				if slot == "main hand" && equippedWeapon.TwoHanded {
 					char.EquippedWeaponOffHand = domain.Weapon{}
 				}
 			} else {
-				return fmt.Errorf("invalid equipment slot specified")
+				return nil, fmt.Errorf("invalid equipment slot specified")
 			}
 		} else {
-			return fmt.Errorf("weapon '%s' not found in SRD data", itemName)
+			return nil, fmt.Errorf("weapon '%s' not found in SRD data", itemName)
 		}
 	case "armor":
-		if a, ok := s.AllArmors[itemName]; ok {
+		if a, ok := s.findArmor(char, itemName); ok {
+			if fromInventory {
+				if err := s.returnToInventory(char, "armor"); err != nil {
+					return nil, err
+				}
+			}
+
 			char.EquippedArmor = a
-			wg.Add(1)
-			<-rateLimiter.C
-			go s.enrichArmor(ctx, &char.EquippedArmor, &wg)
+			if a.Category == "" {
+				wg.Add(1)
+				<-rateLimiter.C
+				go s.enrichArmor(ctx, &char.EquippedArmor, &wg)
+			}
 		} else {
-			return fmt.Errorf("armor '%s' not found in SRD data", itemName)
+			return nil, fmt.Errorf("armor '%s' not found in SRD data", itemName)
 		}
 	case "shield":
-		if sh, ok := s.AllShields[itemName]; ok {
-			char.EquippedShield = sh
+		if sh, ok := s.findShield(char, itemName); ok {
+			// The shield takes the off hand, so an off-hand weapon goes back too
+			if fromInventory {
+				for _, freed := range []string{"shield", "off hand"} {
+					if err := s.returnToInventory(char, freed); err != nil {
+						return nil, err
+					}
+				}
+				if char.EquippedWeaponMainHand.TwoHanded {
+					if err := s.returnToInventory(char, "main hand"); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			if err := char.EquipShield(sh); err != nil {
+				return nil, err
+			}
 		} else {
-			return fmt.Errorf("shield '%s' not found in SRD data", itemName)
+			return nil, fmt.Errorf("shield '%s' not found in SRD data", itemName)
 		}
 	default:
-		return fmt.Errorf("invalid item type: %s. Must be 'weapon', 'armor', or 'shield'", itemType)
+		return nil, fmt.Errorf("invalid item type: %s. Must be 'weapon', 'armor', or 'shield'", itemType)
 	}
 
 	wg.Wait()
 
+	char.UpdateEquipmentProficiencies()
+
+	var warnings []string
+	proficiencySlot := strings.ToLower(itemType)
+	if proficiencySlot == "weapon" {
+		proficiencySlot = slot
+	}
+	if err := char.CheckEquippedProficiency(proficiencySlot); err != nil {
+		if s.Rules.RefuseNonProficientEquipment {
+			return nil, fmt.Errorf("cannot equip %s: %w", itemName, err)
+		}
+		warnings = append(warnings, err.Error())
+	}
+
+	// Magic items are kept apart from the inventory
+	if _, magic := char.FindMagicItem(itemName); fromInventory && !magic {
+		if _, err := char.TakeFromInventory(itemName); err != nil {
+			return nil, fmt.Errorf("cannot equip %s: %w", itemName, err)
+		}
+	}
+
 	char.CalculateCombatStats()
 
 	if err := s.Repo.Save(ctx, char); err != nil {
-		return fmt.Errorf("failed to save character after equipping item: %w", err)
+		return nil, fmt.Errorf("failed to save character after equipping item: %w", err)
 	}
 
-	return nil
+	return warnings, nil
 }
 
-func (s *CharacterService) LearnSpell(ctx context.Context, charName, spellName string) error {
+// LearnSpell adds a spell to the character's known spells for the given
+// class, or for their spellcasting class when none is given.
+func (s *CharacterService) LearnSpell(ctx context.Context, charName, spellName, class string) error {
 	char, err := s.Repo.FindByID(ctx, charName)
 	if err != nil {
 		return err
 	}
 
-	if char.SpellcasterType == domain.NoSpellcasting {
-		return fmt.Errorf("this class can't cast spells")
-	}
-
-	if char.SpellcasterType == domain.PreparedCasting {
-		return fmt.Errorf("this class prepares spells and can't learn them")
-	}
-
-	var spellToLearn domain.Spell
-	found := false
-
-	normalizedSpellName := strings.ToLower(strings.TrimSpace(spellName))
-
-	for _, spellsAtLevel := range s.AllSpells {
-		for _, spell := range spellsAtLevel {
-			if strings.EqualFold(spell.Name, normalizedSpellName) {
-				spellToLearn = spell
-				found = true
-				break
-			}
-		}
-		if found {
-			break
-		}
-	}
-
+	spellToLearn, found := s.findSpell(spellName)
 	if !found {
 		return fmt.Errorf("spell '%s' not found in SRD spell list", spellName)
 	}
 
-	isCasterForSpell := false
-	for _, class := range spellToLearn.Class {
-		if strings.EqualFold(class, char.Class) {
-			isCasterForSpell = true
-			break
-		}
+	if class == "" {
+		class = char.SpellcastingClass()
 	}
 
-	if !isCasterForSpell {
-		return fmt.Errorf("character class '%s' is not listed as a caster for spell '%s'", char.Class, spellName)
-	}
-
-	if _, ok := char.KnownSpells[normalizedSpellName]; ok {
-		return fmt.Errorf("character '%s' already knows the spell '%s'", charName, spellName)
+	if err := char.CheckCanLearnSpell(class, spellToLearn); err != nil {
+		return err
 	}
 
-	char.KnownSpells[normalizedSpellName] = spellToLearn
+	char.AddKnownSpell(class, spellToLearn)
 
-	spellCopy := char.KnownSpells[normalizedSpellName]
+	spellCopy := spellToLearn
 	rateLimiter := time.NewTicker(time.Millisecond * 100)
 	defer rateLimiter.Stop()
 
@@ -323,7 +561,7 @@ func (s *CharacterService) LearnSpell(ctx context.Context, charName, spellName s
 
 	wg.Wait()
 
-	char.KnownSpells[normalizedSpellName] = spellCopy
+	char.KnownSpells[strings.ToLower(spellToLearn.Name)] = spellCopy
 
 	char.CalculateSpellStats()
 	char.CalculateMaxSpellSlots()
@@ -335,20 +573,14 @@ func (s *CharacterService) LearnSpell(ctx context.Context, charName, spellName s
 	return nil
 }
 
-func (s *CharacterService) PrepareSpell(ctx context.Context, charName, spellName string) error {
+// PrepareSpell prepares a spell for the given class, or for the first class
+// that prepares spells and has it on its list when none is given.
+func (s *CharacterService) PrepareSpell(ctx context.Context, charName, spellName, class string) error {
 	char, err := s.Repo.FindByID(ctx, charName)
 	if err != nil {
 		return err
 	}
 
-	if char.SpellcasterType == domain.NoSpellcasting {
-		return fmt.Errorf("this class can't cast spells")
-	}
-
-	if char.SpellcasterType == domain.LearnedCasting {
-		return fmt.Errorf("this class learns spells and can't prepare them")
-	}
-
 	normalizedSpellName := strings.ToLower(strings.TrimSpace(spellName))
 	var spellToPrepare domain.Spell
 	var found bool
@@ -370,47 +602,17 @@ func (s *CharacterService) PrepareSpell(ctx context.Context, charName, spellName
 		return fmt.Errorf("spell '%s' not found in SRD data", spellName)
 	}
 
-	spellLevel := spellToPrepare.Level
-
-	maxSlotLevel := 0
-	for level, count := range char.MaxSpellSlots {
-		if level > maxSlotLevel && count > 0 {
-			maxSlotLevel = level
-		}
-	}
-
-	if spellLevel > maxSlotLevel && maxSlotLevel > 0 {
-		return fmt.Errorf("the spell has higher level than the available spell slots")
-	}
-
-	if count, ok := char.MaxSpellSlots[spellLevel]; !ok || count == 0 {
-		return fmt.Errorf("the spell has higher level than the available spell slots")
-	}
-
-	classData := domain.AllClassesData[strings.ToLower(char.Class)]
-	castingAbility := classData.SpellcastingAbility
-
-	ability, abilityOk := char.AbilityScores[castingAbility]
-	if !abilityOk {
-		return fmt.Errorf("class requires spellcasting ability %s, but score is missing", castingAbility)
-	}
-
-	preparationLimit := (char.Level / 2) + ability.Modifier
-
-	if preparationLimit < 1 {
-		preparationLimit = 1
-	}
-
-	if len(char.PreparedSpells) >= preparationLimit {
-		return fmt.Errorf("character '%s' has reached the limit of %d prepared spells (Lvl %d + %s Mod %+d)",
-			charName, preparationLimit, char.Level, castingAbility, ability.Modifier)
+	preparingClass, err := char.PreparingClass(class, spellToPrepare)
+	if err != nil {
+		return err
 	}
 
-	if _, ok := char.PreparedSpells[normalizedSpellName]; ok {
-		return fmt.Errorf("spell '%s' is already prepared", spellName)
+	if err := char.CheckCanPrepareSpell(preparingClass, spellToPrepare); err != nil {
+		return err
 	}
 
-	char.PreparedSpells[normalizedSpellName] = spellToPrepare
+	normalizedSpellName = strings.ToLower(spellToPrepare.Name)
+	char.AddPreparedSpell(preparingClass, spellToPrepare)
 
 	spellCopy := char.PreparedSpells[normalizedSpellName]
 	rateLimiter := time.NewTicker(time.Millisecond * 100)
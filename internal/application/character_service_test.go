@@ -3,7 +3,10 @@ package application_test
 import (
 	"context"
 	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/dice"
 	"dnd-char-generator/internal/domain"
+	"encoding/json"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -18,6 +21,65 @@ func (m *mockRepo) FindByID(ctx context.Context, name string) (*domain.Character
 func (m *mockRepo) FindAll(ctx context.Context) ([]*domain.Character, error) { return nil, nil }
 func (m *mockRepo) Delete(ctx context.Context, name string) error            { return nil }
 
+// memoryRepo keeps characters in memory for tests that load and save them.
+// Characters are stored encoded, like the file repository does, so changes
+// only stick once saved. Saving a character named in saveErrs fails.
+type memoryRepo struct {
+	chars    map[string][]byte
+	saveErrs map[string]error
+}
+
+func newMemoryRepo(chars ...*domain.Character) *memoryRepo {
+	repo := &memoryRepo{chars: make(map[string][]byte), saveErrs: make(map[string]error)}
+	for _, char := range chars {
+		repo.Save(context.Background(), char)
+	}
+	return repo
+}
+
+func (m *memoryRepo) Save(ctx context.Context, char *domain.Character) error {
+	if err := m.saveErrs[char.Name]; err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(char)
+	if err != nil {
+		return err
+	}
+	m.chars[char.Name] = data
+	return nil
+}
+
+func (m *memoryRepo) FindByID(ctx context.Context, name string) (*domain.Character, error) {
+	data, ok := m.chars[name]
+	if !ok {
+		return nil, fmt.Errorf("character '%s' not found", name)
+	}
+
+	var char domain.Character
+	if err := json.Unmarshal(data, &char); err != nil {
+		return nil, err
+	}
+	return &char, nil
+}
+
+func (m *memoryRepo) FindAll(ctx context.Context) ([]*domain.Character, error) {
+	var chars []*domain.Character
+	for name := range m.chars {
+		char, err := m.FindByID(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		chars = append(chars, char)
+	}
+	return chars, nil
+}
+
+func (m *memoryRepo) Delete(ctx context.Context, name string) error {
+	delete(m.chars, name)
+	return nil
+}
+
 type mockAPIClient struct{}
 
 func (m *mockAPIClient) EnrichSpell(ctx context.Context, spells *domain.Spell)   {}
@@ -32,9 +94,20 @@ func setupService() *application.CharacterService {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 }
 
+// setupServiceWith returns a service over the given characters that rolls
+// dice from a fixed seed.
+func setupServiceWith(chars ...*domain.Character) *application.CharacterService {
+	service := setupService()
+	service.Repo = newMemoryRepo(chars...)
+	service.Roller = dice.NewSeededRoller(1)
+	return service
+}
+
 func TestRacialSkillProficiencies(t *testing.T) {
 	service := setupService()
 
@@ -182,3 +255,280 @@ func TestRacialSkillProficiencies(t *testing.T) {
 		})
 	}
 }
+
+func TestAbilityScoreMethods(t *testing.T) {
+	service := setupService()
+
+	rolled := domain.AssignInOrder(domain.RollAbilityScores(42))
+
+	tests := []struct {
+		name    string
+		method  string
+		seed    int64
+		scores  map[string]int
+		wantErr bool
+	}{
+		{
+			name:   "Standard array (default method)",
+			scores: map[string]int{"STR": 8, "DEX": 10, "CON": 12, "INT": 13, "WIS": 14, "CHA": 15},
+		},
+		{
+			name:    "Standard array rejects arbitrary scores",
+			method:  "standard",
+			scores:  map[string]int{"STR": 10, "DEX": 10, "CON": 10, "INT": 10, "WIS": 10, "CHA": 10},
+			wantErr: true,
+		},
+		{
+			name:   "Point buy within budget",
+			method: "point-buy",
+			scores: map[string]int{"STR": 15, "DEX": 15, "CON": 15, "INT": 8, "WIS": 8, "CHA": 8},
+		},
+		{
+			name:    "Point buy over budget",
+			method:  "point-buy",
+			scores:  map[string]int{"STR": 15, "DEX": 15, "CON": 15, "INT": 9, "WIS": 8, "CHA": 8},
+			wantErr: true,
+		},
+		{
+			name:    "Point buy score out of range",
+			method:  "point-buy",
+			scores:  map[string]int{"STR": 16, "DEX": 8, "CON": 8, "INT": 8, "WIS": 8, "CHA": 8},
+			wantErr: true,
+		},
+		{
+			name:   "Rolled scores matching the seed",
+			method: "rolled",
+			seed:   42,
+			scores: rolled,
+		},
+		{
+			name:    "Rolled scores from a different seed",
+			method:  "rolled",
+			seed:    7,
+			scores:  rolled,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateCharacter(context.Background(), application.CreateCharacterRequest{
+				Name:               tt.name,
+				Race:               "human",
+				Class:              "fighter",
+				Background:         "soldier",
+				Level:              1,
+				ScoreAssignments:   tt.scores,
+				AbilityScoreMethod: tt.method,
+				Seed:               tt.seed,
+			})
+
+			if tt.wantErr && err == nil {
+				t.Errorf("expected an error for %s, got none", tt.name)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error for %s: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestSavingThrowProficiencies(t *testing.T) {
+	service := setupService()
+
+	tests := []struct {
+		name       string
+		class      string
+		wantSaves  []string
+		wantNoSave string
+	}{
+		{name: "Fighter", class: "fighter", wantSaves: []string{"STR", "CON"}, wantNoSave: "DEX"},
+		{name: "Rogue", class: "rogue", wantSaves: []string{"DEX", "INT"}, wantNoSave: "STR"},
+		{name: "Wizard", class: "wizard", wantSaves: []string{"INT", "WIS"}, wantNoSave: "CHA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, err := service.CreateCharacter(context.Background(), application.CreateCharacterRequest{
+				Name:             tt.name,
+				Race:             "human",
+				Class:            tt.class,
+				Background:       "sage",
+				Level:            1,
+				ScoreAssignments: map[string]int{"STR": 8, "DEX": 10, "CON": 12, "INT": 13, "WIS": 14, "CHA": 15},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create character: %v", err)
+			}
+
+			for _, ab := range tt.wantSaves {
+				want := char.AbilityScores[ab].Modifier + char.ProficiencyBonus
+				if got := char.GetSavingThrowModifier(ab); got != want {
+					t.Errorf("%s save = %+d, expected %+d", ab, got, want)
+				}
+			}
+
+			if got := char.GetSavingThrowModifier(tt.wantNoSave); got != char.AbilityScores[tt.wantNoSave].Modifier {
+				t.Errorf("%s save = %+d, expected no proficiency", tt.wantNoSave, got)
+			}
+		})
+	}
+}
+
+func TestStartingWealth(t *testing.T) {
+	service := setupService()
+
+	tests := []struct {
+		name     string
+		class    string
+		gear     string
+		wantGold int
+	}{
+		{name: "NoGear", class: "fighter", gear: "", wantGold: 0},
+		{name: "Fighter", class: "fighter", gear: application.StartingWealth, wantGold: 120},
+		{name: "Rogue", class: "rogue", gear: application.StartingWealth, wantGold: 100},
+		{name: "Monk", class: "monk", gear: application.StartingWealth, wantGold: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, err := service.CreateCharacter(context.Background(), application.CreateCharacterRequest{
+				Name:             tt.name,
+				Race:             "human",
+				Class:            tt.class,
+				Background:       "sage",
+				Level:            1,
+				ScoreAssignments: map[string]int{"STR": 8, "DEX": 10, "CON": 12, "INT": 13, "WIS": 14, "CHA": 15},
+				StartingGear:     tt.gear,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create character: %v", err)
+			}
+
+			if got := char.Purse["gp"]; got != tt.wantGold {
+				t.Errorf("starting gold = %d, expected %d", got, tt.wantGold)
+			}
+		})
+	}
+}
+
+func TestEquipmentProficiencies(t *testing.T) {
+	service := setupService()
+
+	plate := domain.Armor{Equipment: domain.Equipment{Name: "plate armor", Category: "Heavy"}}
+	longsword := domain.Weapon{Equipment: domain.Equipment{Name: "longsword", Category: "Martial Melee"}}
+
+	tests := []struct {
+		name          string
+		race          string
+		class         string
+		wantPlate     bool
+		wantShield    bool
+		wantLongsword bool
+		wantTool      string
+	}{
+		{name: "Fighter", race: "human", class: "fighter", wantPlate: true, wantShield: true, wantLongsword: true},
+		{name: "Wizard", race: "human", class: "wizard"},
+		{name: "ElfWizard", race: "high elf", class: "wizard", wantLongsword: true},
+		{name: "Rogue", race: "gnome", class: "rogue", wantLongsword: true, wantTool: "thieves' tools"},
+		{name: "DwarfCleric", race: "hill dwarf", class: "cleric", wantShield: true, wantTool: "smith's tools"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, err := service.CreateCharacter(context.Background(), application.CreateCharacterRequest{
+				Name:             tt.name,
+				Race:             tt.race,
+				Class:            tt.class,
+				Subclass:         map[string]string{"cleric": "life domain"}[tt.class],
+				Background:       "sage",
+				Level:            1,
+				ScoreAssignments: map[string]int{"STR": 8, "DEX": 10, "CON": 12, "INT": 13, "WIS": 14, "CHA": 15},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create character: %v", err)
+			}
+
+			if got := char.IsProficientWithArmor(plate); got != tt.wantPlate {
+				t.Errorf("plate armor proficiency = %v, expected %v", got, tt.wantPlate)
+			}
+			if got := char.IsProficientWithShield(); got != tt.wantShield {
+				t.Errorf("shield proficiency = %v, expected %v", got, tt.wantShield)
+			}
+			if got := char.IsProficientWithWeapon(longsword); got != tt.wantLongsword {
+				t.Errorf("longsword proficiency = %v, expected %v", got, tt.wantLongsword)
+			}
+			if tt.wantTool != "" && !char.IsProficientWithTool(tt.wantTool) {
+				t.Errorf("expected proficiency with %s, got %s", tt.wantTool, char.ToolProficiencies)
+			}
+
+			char.EquippedArmor = plate
+			if got := char.HasDisadvantageOnChecks("DEX"); got == tt.wantPlate {
+				t.Errorf("disadvantage on DEX checks in plate = %v, expected %v", got, !tt.wantPlate)
+			}
+		})
+	}
+}
+
+func TestCreateWithImprovements(t *testing.T) {
+	service := setupService()
+
+	tests := []struct {
+		name         string
+		class        string
+		level        int
+		improvements []application.ImprovementChoice
+		wantErr      bool
+		wantSTR      int
+		wantFeat     string
+	}{
+		{name: "NoASIYet", class: "wizard", level: 3, wantSTR: 8},
+		{name: "MissingChoice", class: "wizard", level: 8, improvements: []application.ImprovementChoice{
+			{AbilityIncreases: map[string]int{"STR": 2}},
+		}, wantErr: true},
+		{name: "TooManyChoices", class: "wizard", level: 4, improvements: []application.ImprovementChoice{
+			{AbilityIncreases: map[string]int{"STR": 2}},
+			{Feat: "alert"},
+		}, wantErr: true},
+		{name: "IncreaseAndFeat", class: "wizard", level: 8, improvements: []application.ImprovementChoice{
+			{AbilityIncreases: map[string]int{"STR": 2}},
+			{Feat: "alert"},
+		}, wantSTR: 10, wantFeat: "alert"},
+		{name: "FighterExtraASI", class: "fighter", level: 6, improvements: []application.ImprovementChoice{
+			{AbilityIncreases: map[string]int{"STR": 1, "DEX": 1}},
+			{Feat: "resilient", FeatAbility: "CON"},
+		}, wantSTR: 9, wantFeat: "resilient"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, err := service.CreateCharacter(context.Background(), application.CreateCharacterRequest{
+				Name:             tt.name,
+				Race:             "human",
+				Class:            tt.class,
+				Subclass:         map[string]string{"wizard": "school of evocation", "fighter": "champion"}[tt.class],
+				Background:       "sage",
+				Level:            tt.level,
+				ScoreAssignments: map[string]int{"STR": 8, "DEX": 10, "CON": 12, "INT": 13, "WIS": 14, "CHA": 15},
+				Improvements:     tt.improvements,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateCharacter error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			// Humans get +1 to every score
+			if got := char.AbilityScores["STR"].Score; got != tt.wantSTR+1 {
+				t.Errorf("STR = %d, expected %d", got, tt.wantSTR+1)
+			}
+			if tt.wantFeat != "" && !char.HasFeat(tt.wantFeat) {
+				t.Errorf("expected the %s feat, got %v", tt.wantFeat, char.Feats)
+			}
+			if got := len(char.AbilityScoreImprovements); got != len(tt.improvements) {
+				t.Errorf("recorded %d improvements, expected %d", got, len(tt.improvements))
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"dnd-char-generator/internal/dice"
+	"dnd-char-generator/internal/domain"
+)
+
+type AttackResult struct {
+	Attack   domain.WeaponAttack
+	ToHit    dice.Result
+	Damage   dice.Result
+	Critical bool
+	Fumble   bool
+}
+
+// Attack rolls an attack with the weapon in the given hand. A natural 20 is a
+// critical hit and rolls the damage dice twice.
+func (s *CharacterService) Attack(ctx context.Context, name, hand string) (*AttackResult, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	char.UpdateProficiencyBonus(char.Level)
+	char.UpdateEquipmentProficiencies()
+
+	attack, err := char.GetWeaponAttack(hand)
+	if err != nil {
+		return nil, err
+	}
+
+	toHit, err := s.Roller.Roll(attack.AttackExpression())
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll attack: %w", err)
+	}
+
+	// With advantage or disadvantage, the kept d20 is the natural roll used for crits
+	natural := toHit.Terms[0].Total
+
+	damageExpr, err := dice.Parse(attack.DamageExpression())
+	if err != nil {
+		return nil, fmt.Errorf("weapon '%s' has invalid damage '%s': %w", attack.Weapon, attack.DamageExpression(), err)
+	}
+
+	if natural == 20 {
+		for i := range damageExpr.Terms {
+			damageExpr.Terms[i].Count *= 2
+		}
+	}
+
+	return &AttackResult{
+		Attack:   attack,
+		ToHit:    toHit,
+		Damage:   s.Roller.RollExpression(damageExpr),
+		Critical: natural == 20,
+		Fumble:   natural == 1,
+	}, nil
+}
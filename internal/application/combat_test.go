@@ -0,0 +1,138 @@
+package application_test
+
+import (
+	"context"
+	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/dice"
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func newFighter(name string, str, dex int) *domain.Character {
+	abilities := make(map[string]domain.Ability)
+	for ab, score := range map[string]int{"STR": str, "DEX": dex, "CON": 10, "INT": 10, "WIS": 10, "CHA": 10} {
+		a := domain.Ability{Score: score}
+		a.CalculateModifier()
+		abilities[ab] = a
+	}
+
+	return &domain.Character{
+		Name:          name,
+		Race:          "human",
+		Class:         "fighter",
+		Level:         1,
+		ClassLevels:   map[string]int{"fighter": 1},
+		AbilityScores: abilities,
+	}
+}
+
+func TestAttack(t *testing.T) {
+	weapon := func(name, category, damage string, properties ...string) domain.Weapon {
+		return domain.Weapon{
+			Equipment:  domain.Equipment{Name: name, Category: category},
+			Damage:     damage,
+			Properties: properties,
+		}
+	}
+	longsword := weapon("longsword", "Martial Melee", "1d8 slashing", "versatile")
+	longsword.VersatileDamage = "1d10"
+	rapier := weapon("rapier", "Martial Melee", "1d8 piercing", "finesse")
+	longbow := weapon("longbow", "Martial Ranged", "1d8 piercing", "ammunition", "heavy", "two-handed")
+	shortsword := weapon("shortsword", "Martial Melee", "1d6 piercing", "finesse", "light")
+	handaxe := weapon("handaxe", "Simple Melee", "1d6 slashing", "light", "thrown")
+
+	tests := []struct {
+		name        string
+		str, dex    int
+		mainHand    domain.Weapon
+		offHand     domain.Weapon
+		hand        string
+		wantAbility string
+		wantAttack  int
+		wantDamage  string
+	}{
+		{name: "StrengthMelee", str: 16, dex: 14, mainHand: longsword, hand: "main", wantAbility: "STR", wantAttack: 5, wantDamage: "1d10+3 slashing"},
+		{name: "FinesseUsesBetter", str: 10, dex: 16, mainHand: rapier, hand: "main", wantAbility: "DEX", wantAttack: 5, wantDamage: "1d8+3 piercing"},
+		{name: "FinesseKeepsStrength", str: 16, dex: 12, mainHand: rapier, hand: "main", wantAbility: "STR", wantAttack: 5, wantDamage: "1d8+3 piercing"},
+		{name: "RangedUsesDex", str: 18, dex: 12, mainHand: longbow, hand: "main", wantAbility: "DEX", wantAttack: 3, wantDamage: "1d8+1 piercing"},
+		{name: "OffHandDropsBonus", str: 10, dex: 16, mainHand: shortsword, offHand: shortsword, hand: "off", wantAbility: "DEX", wantAttack: 5, wantDamage: "1d6 piercing"},
+		{name: "OffHandKeepsPenalty", str: 8, dex: 8, mainHand: handaxe, offHand: handaxe, hand: "off", wantAbility: "STR", wantAttack: 1, wantDamage: "1d6-1 slashing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newFighter(tt.name, tt.str, tt.dex)
+			char.EquippedWeaponMainHand = tt.mainHand
+			char.EquippedWeaponOffHand = tt.offHand
+			service := setupServiceWith(char)
+
+			result, err := service.Attack(context.Background(), tt.name, tt.hand)
+			if err != nil {
+				t.Fatalf("Failed to attack: %v", err)
+			}
+
+			if result.Attack.Ability != tt.wantAbility {
+				t.Errorf("ability = %s, expected %s", result.Attack.Ability, tt.wantAbility)
+			}
+			if result.Attack.AttackBonus != tt.wantAttack {
+				t.Errorf("attack bonus = %+d, expected %+d", result.Attack.AttackBonus, tt.wantAttack)
+			}
+			if got := result.Attack.DamageExpression(); got != tt.wantDamage {
+				t.Errorf("damage = %s, expected %s", got, tt.wantDamage)
+			}
+
+			natural := result.ToHit.Terms[0].Total
+			if result.ToHit.Total != natural+tt.wantAttack {
+				t.Errorf("attack roll = %d, expected d20 %d %+d", result.ToHit.Total, natural, tt.wantAttack)
+			}
+		})
+	}
+
+	t.Run("CriticalDoublesDice", func(t *testing.T) {
+		char := newFighter("Crit", 16, 10)
+		char.EquippedWeaponMainHand = rapier
+		service := setupServiceWith(char)
+
+		var hit, crit *application.AttackResult
+		for seed := int64(1); seed <= 200 && (hit == nil || crit == nil); seed++ {
+			service.Roller = dice.NewSeededRoller(seed)
+			result, err := service.Attack(context.Background(), "Crit", "main")
+			if err != nil {
+				t.Fatalf("Failed to attack: %v", err)
+			}
+			if result.Critical {
+				crit = result
+			} else {
+				hit = result
+			}
+		}
+		if hit == nil || crit == nil {
+			t.Fatalf("expected both a normal hit and a critical in 200 seeds")
+		}
+
+		if got := len(hit.Damage.Terms[0].Dice); got != 1 {
+			t.Errorf("normal hit rolled %d damage dice, expected 1", got)
+		}
+		if got := len(crit.Damage.Terms[0].Dice); got != 2 {
+			t.Errorf("critical hit rolled %d damage dice, expected 2", got)
+		}
+		if crit.ToHit.Terms[0].Total != 20 {
+			t.Errorf("critical on a natural %d", crit.ToHit.Terms[0].Total)
+		}
+		// Only the dice are doubled, not the STR modifier
+		if crit.Damage.Total < 5 || crit.Damage.Total > 19 {
+			t.Errorf("critical damage = %d, expected 2d8+3", crit.Damage.Total)
+		}
+	})
+
+	t.Run("OffHandNeedsLightWeapons", func(t *testing.T) {
+		char := newFighter("Clumsy", 16, 10)
+		char.EquippedWeaponMainHand = longsword
+		char.EquippedWeaponOffHand = handaxe
+		service := setupServiceWith(char)
+
+		if _, err := service.Attack(context.Background(), "Clumsy", "off"); err == nil {
+			t.Errorf("expected an error attacking off hand without light weapons in both hands")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// recalculateStats updates every derived statistic effects can change.
+func (s *CharacterService) recalculateStats(char *domain.Character) {
+	char.UpdateProficiencyBonus(char.Level)
+	char.CalculateMaxHitPoints()
+	char.CalculateCombatStats()
+	char.CalculateSpellStats()
+	char.CalculateSpeed(s.Rules.VariantEncumbrance)
+}
+
+// ApplyCondition puts a condition on the character, or adds exhaustion levels.
+func (s *CharacterService) ApplyCondition(ctx context.Context, name string, cond domain.Condition) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if char.Dead {
+		return nil, fmt.Errorf("character '%s' is dead", char.Name)
+	}
+
+	if err := char.ApplyCondition(cond); err != nil {
+		return nil, err
+	}
+
+	s.recalculateStats(char)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
+
+// RemoveCondition ends one of the character's conditions, or removes one
+// level of exhaustion.
+func (s *CharacterService) RemoveCondition(ctx context.Context, name, condition string) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := char.RemoveCondition(condition); err != nil {
+		return nil, err
+	}
+
+	s.recalculateStats(char)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
+
+// PassTime counts down the character's conditions by a number of rounds and
+// returns the ones that ended.
+func (s *CharacterService) PassTime(ctx context.Context, name string, rounds int) (*domain.Character, []string, error) {
+	if rounds <= 0 {
+		return nil, nil, fmt.Errorf("number of rounds must be at least 1")
+	}
+
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ended := char.PassRounds(rounds)
+	s.recalculateStats(char)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, ended, nil
+}
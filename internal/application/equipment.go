@@ -0,0 +1,21 @@
+package application
+
+import "dnd-char-generator/internal/domain"
+
+// refreshEquipment replaces the saved copies of equipped items with the
+// current catalogue entries, so characters saved before an item had its
+// cost, weight or properties recorded pick them up.
+func (s *CharacterService) refreshEquipment(char *domain.Character) {
+	if w, ok := s.AllWeapons[char.EquippedWeaponMainHand.Name]; ok {
+		char.EquippedWeaponMainHand = w
+	}
+	if w, ok := s.AllWeapons[char.EquippedWeaponOffHand.Name]; ok {
+		char.EquippedWeaponOffHand = w
+	}
+	if a, ok := s.AllArmors[char.EquippedArmor.Name]; ok {
+		char.EquippedArmor = a
+	}
+	if sh, ok := s.AllShields[char.EquippedShield.Name]; ok {
+		char.EquippedShield = sh
+	}
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"dnd-char-generator/internal/dice"
+	"dnd-char-generator/internal/domain"
+)
+
+type DamageRequest struct {
+	Name     string
+	Amount   int
+	Critical bool
+
+	// RollConcentration rolls the CON save for a concentrating character
+	// instead of only reporting the DC.
+	RollConcentration bool
+}
+
+type DamageOutcome struct {
+	domain.DamageResult
+	ConcentrationSave *dice.Result
+	KeptConcentration bool
+}
+
+func (s *CharacterService) DamageCharacter(ctx context.Context, req DamageRequest) (*domain.Character, *DamageOutcome, error) {
+	char, err := s.Repo.FindByID(ctx, req.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	char.UpdateProficiencyBonus(char.Level)
+
+	result, err := char.TakeDamage(req.Amount, req.Critical)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outcome := &DamageOutcome{DamageResult: result}
+
+	if result.ConcentrationDC > 0 && req.RollConcentration {
+		save, err := s.Roller.Roll(char.SavingThrowBreakdown("CON").RollExpression())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to roll concentration save: %w", err)
+		}
+
+		outcome.ConcentrationSave = &save
+		outcome.KeptConcentration = char.ResolveConcentrationSave(save.Total, result.ConcentrationDC)
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, outcome, nil
+}
+
+// HealCharacter restores hit points and returns how many were regained.
+func (s *CharacterService) HealCharacter(ctx context.Context, name string, amount int) (*domain.Character, int, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	healed, err := char.Heal(amount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, 0, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, healed, nil
+}
+
+// GrantTemporaryHitPoints gives a character temporary hit points. The returned
+// bool is false when the character already had as many or more.
+func (s *CharacterService) GrantTemporaryHitPoints(ctx context.Context, name string, amount int) (*domain.Character, bool, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	granted, err := char.GrantTemporaryHitPoints(amount)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, false, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, granted, nil
+}
+
+// RollDeathSave rolls a d20 death saving throw for a dying character.
+func (s *CharacterService) RollDeathSave(ctx context.Context, name string) (*domain.Character, domain.DeathSaveResult, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, domain.DeathSaveResult{}, err
+	}
+
+	roll, err := s.Roller.Roll("1d20")
+	if err != nil {
+		return nil, domain.DeathSaveResult{}, fmt.Errorf("failed to roll death save: %w", err)
+	}
+
+	result, err := char.RecordDeathSave(roll.Total)
+	if err != nil {
+		return nil, result, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, result, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, result, nil
+}
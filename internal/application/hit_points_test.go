@@ -0,0 +1,85 @@
+package application_test
+
+import (
+	"context"
+	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/dice"
+	"testing"
+)
+
+// fixedRNG makes every die land on the same face.
+type fixedRNG int
+
+func (f fixedRNG) IntN(n int) int { return min(int(f), n) - 1 }
+
+func TestDamageConcentrationSave(t *testing.T) {
+	tests := []struct {
+		name      string
+		face      int
+		damage    int
+		wantTotal int
+		wantKept  bool
+		wantSpell string
+	}{
+		// Fighters add their proficiency bonus to CON saves
+		{name: "Passed", face: 20, damage: 22, wantTotal: 22, wantKept: true, wantSpell: "Bless"},
+		{name: "Failed", face: 1, damage: 22, wantTotal: 3},
+		{name: "FailedByOne", face: 8, damage: 22, wantTotal: 10},
+		{name: "MetMinimumDC", face: 8, damage: 9, wantTotal: 10, wantKept: true, wantSpell: "Bless"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newFighter(tt.name, 10, 10)
+			char.MaxHitPoints = 40
+			char.CurrentHitPoints = 40
+			char.ConcentratingOn = "Bless"
+			char.SetClassSavingThrows()
+			service := setupServiceWith(char)
+			service.Roller = dice.NewRoller(fixedRNG(tt.face))
+
+			_, outcome, err := service.DamageCharacter(context.Background(), application.DamageRequest{
+				Name: tt.name, Amount: tt.damage, RollConcentration: true,
+			})
+			if err != nil {
+				t.Fatalf("Failed to damage character: %v", err)
+			}
+
+			if outcome.ConcentrationSave == nil {
+				t.Fatalf("expected a concentration save to be rolled")
+			}
+			if outcome.ConcentrationSave.Total != tt.wantTotal {
+				t.Errorf("save total = %d, expected %d", outcome.ConcentrationSave.Total, tt.wantTotal)
+			}
+			if outcome.KeptConcentration != tt.wantKept {
+				t.Errorf("kept concentration = %v, expected %v", outcome.KeptConcentration, tt.wantKept)
+			}
+
+			saved, _ := service.Repo.FindByID(context.Background(), tt.name)
+			if saved.ConcentratingOn != tt.wantSpell {
+				t.Errorf("saved character concentrating on %q, expected %q", saved.ConcentratingOn, tt.wantSpell)
+			}
+		})
+	}
+
+	t.Run("KnockedOutNoSave", func(t *testing.T) {
+		char := newFighter("Downed", 10, 10)
+		char.MaxHitPoints = 40
+		char.CurrentHitPoints = 5
+		char.ConcentratingOn = "Bless"
+		service := setupServiceWith(char)
+
+		saved, outcome, err := service.DamageCharacter(context.Background(), application.DamageRequest{
+			Name: "Downed", Amount: 9, RollConcentration: true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to damage character: %v", err)
+		}
+		if outcome.ConcentrationSave != nil {
+			t.Errorf("expected no save when knocked out")
+		}
+		if outcome.LostConcentration != "Bless" || saved.ConcentratingOn != "" {
+			t.Errorf("expected concentration on Bless to end at 0 HP, still on %q", saved.ConcentratingOn)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// findItem looks an item of any type up in the equipment catalogue.
+func (s *CharacterService) findItem(name string) (domain.Equipment, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+
+	if w, ok := s.AllWeapons[key]; ok {
+		return w.Equipment, true
+	}
+	if a, ok := s.AllArmors[key]; ok {
+		return a.Equipment, true
+	}
+	if sh, ok := s.AllShields[key]; ok {
+		return sh.Equipment, true
+	}
+	if g, ok := s.AllGear[key]; ok {
+		return g, true
+	}
+	return domain.Equipment{}, false
+}
+
+// AddItem puts count of a catalogue item in the character's inventory, loose
+// or in a container they carry.
+func (s *CharacterService) AddItem(ctx context.Context, charName, itemName string, count int, container string) (*domain.Character, domain.InventoryItem, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, domain.InventoryItem{}, err
+	}
+
+	item, ok := s.findItem(itemName)
+	if !ok {
+		return nil, domain.InventoryItem{}, fmt.Errorf("item '%s' not found in SRD data", itemName)
+	}
+
+	stack, err := char.AddToInventory(item, count, container)
+	if err != nil {
+		return nil, domain.InventoryItem{}, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, domain.InventoryItem{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, stack, nil
+}
+
+// RemoveItem takes count of an item out of the character's inventory.
+func (s *CharacterService) RemoveItem(ctx context.Context, charName, itemName string, count int, container string) (*domain.Character, domain.InventoryItem, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, domain.InventoryItem{}, err
+	}
+
+	removed, err := char.RemoveFromInventory(itemName, count, container)
+	if err != nil {
+		return nil, domain.InventoryItem{}, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, domain.InventoryItem{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, removed, nil
+}
+
+// Unequip moves the item in an equipment slot to the character's inventory.
+func (s *CharacterService) Unequip(ctx context.Context, charName, slot string) (*domain.Character, domain.Equipment, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, domain.Equipment{}, err
+	}
+
+	char.UpdateProficiencyBonus(char.Level)
+
+	item, err := char.UnequipSlot(slot)
+	if err != nil {
+		return nil, domain.Equipment{}, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, domain.Equipment{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, item, nil
+}
+
+// TransferItem hands count of an item from one character's inventory, loose
+// or out of a container, to another character, who receives it loose. Both
+// sides are checked before anything is saved, and the receiver is saved
+// first and rolled back if the giver can't be saved, so a failed save never
+// loses the item.
+func (s *CharacterService) TransferItem(ctx context.Context, fromName, toName, itemName string, count int, container string) (domain.InventoryItem, error) {
+	if strings.EqualFold(strings.TrimSpace(fromName), strings.TrimSpace(toName)) {
+		return domain.InventoryItem{}, fmt.Errorf("can't transfer items to the same character")
+	}
+
+	from, err := s.Repo.FindByID(ctx, fromName)
+	if err != nil {
+		return domain.InventoryItem{}, err
+	}
+
+	to, err := s.Repo.FindByID(ctx, toName)
+	if err != nil {
+		return domain.InventoryItem{}, err
+	}
+
+	moved, err := from.RemoveFromInventory(itemName, count, container)
+	if err != nil {
+		return domain.InventoryItem{}, err
+	}
+
+	received, err := to.AddToInventory(moved.Item, moved.Count, "")
+	if err != nil {
+		return domain.InventoryItem{}, err
+	}
+
+	if err := s.Repo.Save(ctx, to); err != nil {
+		return domain.InventoryItem{}, fmt.Errorf("failed to save character '%s': %w", to.Name, err)
+	}
+	if err := s.Repo.Save(ctx, from); err != nil {
+		if undoErr := s.undoTransfer(ctx, to, moved); undoErr != nil {
+			return domain.InventoryItem{}, fmt.Errorf("failed to save character '%s': %w; undoing the transfer to '%s' also failed: %v",
+				from.Name, err, to.Name, undoErr)
+		}
+		return domain.InventoryItem{}, fmt.Errorf("failed to save character '%s': %w", from.Name, err)
+	}
+
+	received.Count = moved.Count
+	return received, nil
+}
+
+// undoTransfer takes transferred items back off the receiver when the giver
+// couldn't be saved, so they aren't carried by both.
+func (s *CharacterService) undoTransfer(ctx context.Context, to *domain.Character, moved domain.InventoryItem) error {
+	if _, err := to.RemoveFromInventory(moved.Item.Name, moved.Count, ""); err != nil {
+		return err
+	}
+	return s.Repo.Save(ctx, to)
+}
+
+// returnToInventory unequips whatever is in a slot into the inventory, doing
+// nothing when the slot is empty.
+func (s *CharacterService) returnToInventory(char *domain.Character, slot string) error {
+	if char.EquippedItem(slot).Name == "" {
+		return nil
+	}
+	_, err := char.UnequipSlot(slot)
+	return err
+}
@@ -0,0 +1,141 @@
+package application_test
+
+import (
+	"context"
+	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/domain"
+	"errors"
+	"testing"
+)
+
+func TestTransferItem(t *testing.T) {
+	torch := domain.Equipment{Name: "torch", Weight: 1}
+	rope := domain.Equipment{Name: "rope, hempen (50 feet)", Weight: 10}
+	backpack := domain.Equipment{Name: "backpack", Weight: 5}
+
+	newPair := func() (*domain.Character, *domain.Character) {
+		giver := &domain.Character{Name: "Giver"}
+		giver.AddToInventory(torch, 3, "")
+		giver.AddToInventory(backpack, 1, "")
+		giver.AddToInventory(rope, 1, "backpack")
+		return giver, &domain.Character{Name: "Taker"}
+	}
+	carried := func(t *testing.T, service interface {
+		GetCharacter(context.Context, string) (*domain.Character, error)
+	}, name, item string) int {
+		t.Helper()
+		char, err := service.GetCharacter(context.Background(), name)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		return char.CarriedCount(item)
+	}
+
+	tests := []struct {
+		name      string
+		item      string
+		count     int
+		container string
+		failSave  string
+		wantErr   bool
+		wantGiver int
+		wantTaker int
+	}{
+		{name: "Loose", item: "torch", count: 2, wantGiver: 1, wantTaker: 2},
+		{name: "FromContainer", item: "rope, hempen (50 feet)", count: 1, container: "backpack", wantGiver: 0, wantTaker: 1},
+		{name: "TooMany", item: "torch", count: 4, wantErr: true, wantGiver: 3},
+		{name: "ReceiverSaveFails", item: "torch", count: 2, failSave: "Taker", wantErr: true, wantGiver: 3},
+		{name: "GiverSaveFails", item: "torch", count: 2, failSave: "Giver", wantErr: true, wantGiver: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := setupServiceWith(newPair())
+			if tt.failSave != "" {
+				service.Repo.(*memoryRepo).saveErrs[tt.failSave] = errors.New("disk full")
+			}
+
+			received, err := service.TransferItem(context.Background(), "Giver", "Taker", tt.item, tt.count, tt.container)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TransferItem error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err == nil && (received.Count != tt.count || received.Container != "") {
+				t.Errorf("received %+v, expected %d loose", received, tt.count)
+			}
+
+			delete(service.Repo.(*memoryRepo).saveErrs, tt.failSave)
+			if got := carried(t, service, "Giver", tt.item); got != tt.wantGiver {
+				t.Errorf("giver carries %d, expected %d", got, tt.wantGiver)
+			}
+			if got := carried(t, service, "Taker", tt.item); got != tt.wantTaker {
+				t.Errorf("taker carries %d, expected %d", got, tt.wantTaker)
+			}
+		})
+	}
+
+	service := setupServiceWith(newPair())
+	if _, err := service.TransferItem(context.Background(), "Giver", "giver", "torch", 1, ""); err == nil {
+		t.Errorf("expected an error transferring to the same character")
+	}
+}
+
+func TestEquipFromInventory(t *testing.T) {
+	dagger := domain.Weapon{
+		Equipment:  domain.Equipment{Name: "dagger", Category: "Simple Melee", Weight: 1},
+		Damage:     "1d4 piercing",
+		Properties: []string{"finesse", "light"},
+	}
+	greatsword := domain.Weapon{
+		Equipment:  domain.Equipment{Name: "greatsword", Category: "Martial Melee", Weight: 6},
+		Damage:     "2d6 slashing",
+		Properties: []string{"heavy", "two-handed"},
+		TwoHanded:  true,
+	}
+	shield := domain.Shield{Equipment: domain.Equipment{Name: "shield", Weight: 6}, ACBonus: 2}
+
+	newService := func() *application.CharacterService {
+		char := newFighter("Armed", 16, 10)
+		char.EquippedWeaponMainHand = dagger
+		char.EquippedShield = shield
+		char.AddToInventory(greatsword.Equipment, 1, "")
+
+		service := setupServiceWith(char)
+		service.Rules.EquipFromInventory = true
+		service.AllWeapons = map[string]domain.Weapon{"dagger": dagger, "greatsword": greatsword}
+		service.AllShields = map[string]domain.Shield{"shield": shield}
+		return service
+	}
+	ctx := context.Background()
+
+	t.Run("SwapsWithInventory", func(t *testing.T) {
+		service := newService()
+		if _, _, err := service.Unequip(ctx, "Armed", "main hand"); err != nil {
+			t.Fatalf("Failed to unequip: %v", err)
+		}
+		if _, err := service.EquipItem(ctx, "Armed", "greatsword", "weapon", "main hand"); err != nil {
+			t.Fatalf("Failed to equip: %v", err)
+		}
+
+		char, err := service.GetCharacter(ctx, "Armed")
+		if err != nil {
+			t.Fatalf("Failed to load: %v", err)
+		}
+		if char.EquippedWeaponMainHand.Name != "greatsword" || char.CarriedCount("greatsword") != 0 {
+			t.Errorf("expected the greatsword taken out of the inventory into the main hand")
+		}
+		if char.CarriedCount("dagger") != 1 {
+			t.Errorf("expected the unequipped dagger in the inventory, got %+v", char.Inventory)
+		}
+		// The two-handed weapon needs the shield's hand
+		if char.EquippedShield.Name != "" || char.CarriedCount("shield") != 1 {
+			t.Errorf("expected the shield put back in the inventory, got %+v", char.Inventory)
+		}
+	})
+
+	t.Run("NotCarried", func(t *testing.T) {
+		service := newService()
+		if _, err := service.EquipItem(ctx, "Armed", "dagger", "weapon", "off hand"); err == nil {
+			t.Errorf("expected an error equipping a weapon that isn't in the inventory")
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// findWeapon looks a weapon up in the catalogue, then among the character's
+// magic weapons.
+func (s *CharacterService) findWeapon(char *domain.Character, name string) (domain.Weapon, bool) {
+	if w, ok := s.AllWeapons[name]; ok {
+		return w, true
+	}
+	if i, ok := char.FindMagicItem(name); ok && char.MagicItems[i].Weapon != nil {
+		return *char.MagicItems[i].Weapon, true
+	}
+	return domain.Weapon{}, false
+}
+
+// findArmor looks armor up in the catalogue, then among the character's magic armor.
+func (s *CharacterService) findArmor(char *domain.Character, name string) (domain.Armor, bool) {
+	if a, ok := s.AllArmors[name]; ok {
+		return a, true
+	}
+	if i, ok := char.FindMagicItem(name); ok && char.MagicItems[i].Armor != nil {
+		return *char.MagicItems[i].Armor, true
+	}
+	return domain.Armor{}, false
+}
+
+// findShield looks a shield up in the catalogue, then among the character's
+// magic shields.
+func (s *CharacterService) findShield(char *domain.Character, name string) (domain.Shield, bool) {
+	if sh, ok := s.AllShields[name]; ok {
+		return sh, true
+	}
+	if i, ok := char.FindMagicItem(name); ok && char.MagicItems[i].Shield != nil {
+		return *char.MagicItems[i].Shield, true
+	}
+	return domain.Shield{}, false
+}
+
+// AddMagicItem gives the character a magic item from the catalogue. Magic
+// weapons, armor and shields are made from a base item, e.g. a "+1 weapon"
+// from a longsword.
+func (s *CharacterService) AddMagicItem(ctx context.Context, charName, itemName, base string) (*domain.Character, domain.MagicItem, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, domain.MagicItem{}, err
+	}
+
+	item, ok := s.MagicItems[strings.ToLower(strings.TrimSpace(itemName))]
+	if !ok {
+		return nil, domain.MagicItem{}, fmt.Errorf("magic item '%s' not found", itemName)
+	}
+
+	base = strings.ToLower(strings.TrimSpace(base))
+	var (
+		weapon *domain.Weapon
+		armor  *domain.Armor
+		shield *domain.Shield
+	)
+	if w, ok := s.AllWeapons[base]; ok {
+		weapon = &w
+	}
+	if a, ok := s.AllArmors[base]; ok {
+		armor = &a
+	}
+	if sh, ok := s.AllShields[base]; ok {
+		shield = &sh
+	}
+
+	item, err = item.WrapBase(weapon, armor, shield)
+	if err != nil {
+		return nil, domain.MagicItem{}, err
+	}
+	if _, ok := char.FindMagicItem(item.Name); ok {
+		return nil, domain.MagicItem{}, fmt.Errorf("character '%s' already has '%s'", char.Name, item.Name)
+	}
+
+	item.Charges = item.MaxCharges
+	char.MagicItems = append(char.MagicItems, item)
+
+	s.recalculateStats(char)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, domain.MagicItem{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, item, nil
+}
+
+// AttuneItem attunes the character to one of their magic items, or ends the
+// attunement.
+func (s *CharacterService) AttuneItem(ctx context.Context, charName, itemName string, end bool) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, err
+	}
+
+	if end {
+		err = char.EndAttunement(itemName)
+	} else {
+		err = char.Attune(itemName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.recalculateStats(char)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
+
+// UseMagicItemCharges expends charges of one of the character's magic items.
+func (s *CharacterService) UseMagicItemCharges(ctx context.Context, charName, itemName string, count int) (domain.MagicItem, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return domain.MagicItem{}, err
+	}
+
+	item, err := char.UseCharges(itemName, count)
+	if err != nil {
+		return domain.MagicItem{}, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return domain.MagicItem{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return item, nil
+}
+
+// rechargeMagicItems rolls the charges each of the character's charged magic
+// items regains at dawn.
+func (s *CharacterService) rechargeMagicItems(char *domain.Character) error {
+	for i, item := range char.MagicItems {
+		if item.MaxCharges == 0 || item.Recharge == "" {
+			continue
+		}
+
+		roll, err := s.Roller.Roll(item.Recharge)
+		if err != nil {
+			return fmt.Errorf("failed to roll charges for '%s': %w", item.Name, err)
+		}
+		char.RechargeMagicItem(i, roll.Total)
+	}
+	return nil
+}
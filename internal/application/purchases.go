@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// Starting gear options for CreateCharacterRequest.StartingGear.
+const (
+	StartingEquipment = "equipment"
+	StartingWealth    = "wealth"
+)
+
+// Trade describes a purchase or sale: the items that changed hands and the
+// price in copper pieces.
+type Trade struct {
+	Item  domain.InventoryItem
+	Price int
+}
+
+// itemPrice is what count items cost, charging a share of the bundle price
+// for items sold in bundles and rounding up to the next copper piece.
+func itemPrice(item domain.Equipment, count int) int {
+	bundle := max(item.Quantity, 1)
+	return (item.Cost*count + bundle - 1) / bundle
+}
+
+// BuyItem pays the SRD price for count items out of the character's purse and
+// puts them in the inventory, loose or in a carried container.
+func (s *CharacterService) BuyItem(ctx context.Context, charName, itemName string, count int, container string) (*domain.Character, Trade, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, Trade{}, err
+	}
+
+	item, ok := s.findItem(itemName)
+	if !ok {
+		return nil, Trade{}, fmt.Errorf("item '%s' not found in SRD data", itemName)
+	}
+
+	if count <= 0 {
+		return nil, Trade{}, fmt.Errorf("item count must be at least 1")
+	}
+
+	price := itemPrice(item, count)
+	if err := char.Spend(price); err != nil {
+		return nil, Trade{}, fmt.Errorf("can't afford %d %s for %s: %w", count, item.Name, domain.FormatCost(price), err)
+	}
+
+	stack, err := char.AddToInventory(item, count, container)
+	if err != nil {
+		return nil, Trade{}, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, Trade{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	stack.Count = count
+	return char, Trade{Item: stack, Price: price}, nil
+}
+
+// SellItem sells count items from the character's inventory for half their
+// SRD price.
+func (s *CharacterService) SellItem(ctx context.Context, charName, itemName string, count int, container string) (*domain.Character, Trade, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, Trade{}, err
+	}
+
+	sold, err := char.RemoveFromInventory(itemName, count, container)
+	if err != nil {
+		return nil, Trade{}, err
+	}
+
+	price := itemPrice(sold.Item, sold.Count) / 2
+	char.ReceiveCopper(price)
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, Trade{}, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, Trade{Item: sold, Price: price}, nil
+}
+
+// AddCoins puts coins such as "15 gp" in the character's purse.
+func (s *CharacterService) AddCoins(ctx context.Context, charName, amount string) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, err
+	}
+
+	coin, count, err := domain.ParseCoins(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := char.AddCoins(coin, count); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
+
+// SpendCoins pays an amount such as "3 sp" out of the character's purse,
+// making change as needed.
+func (s *CharacterService) SpendCoins(ctx context.Context, charName, amount string) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, err
+	}
+
+	copper, err := domain.ParseCost(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := char.Spend(copper); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
+
+// applyStartingGear gives a new character its class's starting equipment or
+// starting wealth, fixed at the average or rolled.
+func (s *CharacterService) applyStartingGear(char *domain.Character, req CreateCharacterRequest) error {
+	className := strings.ToLower(req.Class)
+
+	switch strings.ToLower(req.StartingGear) {
+	case "":
+		return nil
+	case StartingEquipment:
+		for _, starting := range domain.StartingEquipment[className] {
+			item, ok := s.findItem(starting.Name)
+			if !ok {
+				return fmt.Errorf("starting equipment item '%s' not found in SRD data", starting.Name)
+			}
+			if _, err := char.AddToInventory(item, starting.Count, ""); err != nil {
+				return err
+			}
+		}
+	case StartingWealth:
+		wealth, ok := domain.StartingWealth[className]
+		if !ok {
+			return fmt.Errorf("no starting wealth for class '%s'", className)
+		}
+
+		gold := wealth.AverageGold()
+		if req.RollWealth {
+			roll, err := s.Roller.Roll(wealth.Expression())
+			if err != nil {
+				return err
+			}
+			gold = wealth.Gold(roll.Total)
+		}
+
+		return char.AddCoins("gp", gold)
+	default:
+		return fmt.Errorf("invalid starting gear '%s'. Must be '%s' or '%s'", req.StartingGear, StartingEquipment, StartingWealth)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"dnd-char-generator/internal/dice"
+	"dnd-char-generator/internal/domain"
+)
+
+type ShortRestResult struct {
+	Rolls             []dice.Result
+	HitPointsRegained int
+}
+
+// ShortRest spends up to hitDice hit dice, largest first, each healing the
+// rolled amount plus the CON modifier, then recharges short rest features.
+func (s *CharacterService) ShortRest(ctx context.Context, name string, hitDice int) (*domain.Character, *ShortRestResult, error) {
+	if hitDice < 0 {
+		return nil, nil, fmt.Errorf("number of hit dice can't be negative")
+	}
+
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	char.UpdateClassFeatures(s.ClassFeatures)
+
+	result := &ShortRestResult{}
+
+	for i := 0; i < hitDice; i++ {
+		size, ok := char.NextHitDie()
+		if !ok {
+			return nil, nil, fmt.Errorf("character '%s' has only %d hit dice left", char.Name, i)
+		}
+
+		roll, err := s.Roller.Roll(fmt.Sprintf("1d%d", size))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to roll hit die: %w", err)
+		}
+
+		healed, err := char.SpendHitDie(size, roll.Total)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result.Rolls = append(result.Rolls, roll)
+		result.HitPointsRegained += healed
+	}
+
+	if err := char.FinishShortRest(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, result, nil
+}
+
+func (s *CharacterService) LongRest(ctx context.Context, name string) (*domain.Character, error) {
+	char, err := s.Repo.FindByID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	char.CalculateMaxHitPoints()
+	char.UpdateClassFeatures(s.ClassFeatures)
+
+	if err := char.FinishLongRest(); err != nil {
+		return nil, err
+	}
+	if err := s.rechargeMagicItems(char); err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character: %w", err)
+	}
+
+	return char, nil
+}
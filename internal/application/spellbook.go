@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// AddToSpellbook writes a wizard spell into the character's spellbook. An
+// empty source uses one of the free spells gained on level up, "scroll" or
+// "spellbook" copies it and records the gold and hours spent.
+func (s *CharacterService) AddToSpellbook(ctx context.Context, charName, spellName, source string) (*domain.SpellbookEntry, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, err
+	}
+
+	spell, ok := s.findSpell(spellName)
+	if !ok {
+		return nil, fmt.Errorf("spell '%s' not found in SRD spell list", spellName)
+	}
+
+	class := ""
+	for _, name := range char.ClassNames() {
+		if domain.AllClassesData[name].UsesSpellbook {
+			class = name
+			break
+		}
+	}
+
+	if class == "" {
+		return nil, fmt.Errorf("character '%s' has no class that keeps a spellbook", charName)
+	}
+
+	entry, err := char.AddToSpellbook(class, spell, source)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, fmt.Errorf("failed to save character after updating spellbook: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func (s *CharacterService) findSpell(name string) (domain.Spell, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+
+	for _, spellsAtLevel := range s.AllSpells {
+		for _, spell := range spellsAtLevel {
+			if strings.EqualFold(spell.Name, normalized) {
+				return spell, true
+			}
+		}
+	}
+
+	return domain.Spell{}, false
+}
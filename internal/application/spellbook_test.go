@@ -0,0 +1,136 @@
+package application_test
+
+import (
+	"context"
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestPrepareFromSpellbook(t *testing.T) {
+	newCaster := func(name, class, ability string) *domain.Character {
+		score := domain.Ability{Score: 16}
+		score.CalculateModifier()
+		char := &domain.Character{
+			Name:           name,
+			Class:          class,
+			Level:          3,
+			ClassLevels:    map[string]int{class: 3},
+			AbilityScores:  map[string]domain.Ability{ability: score},
+			KnownSpells:    make(map[string]domain.Spell),
+			PreparedSpells: make(map[string]domain.Spell),
+		}
+		char.CalculateSpellStats()
+		char.CalculateMaxSpellSlots()
+		return char
+	}
+
+	wizard := newCaster("Wiz", "wizard", "INT")
+	cleric := newCaster("Cler", "cleric", "WIS")
+	service := setupServiceWith(wizard, cleric)
+	service.AllSpells = map[int][]domain.Spell{
+		1: {
+			{Name: "Shield", Level: 1, Class: []string{"Wizard"}},
+			{Name: "Cure Wounds", Level: 1, Class: []string{"Cleric"}},
+		},
+	}
+	ctx := context.Background()
+
+	if err := service.PrepareSpell(ctx, "Wiz", "shield", ""); err == nil {
+		t.Errorf("expected an error preparing a spell that isn't in the spellbook")
+	}
+
+	if _, err := service.AddToSpellbook(ctx, "Wiz", "shield", ""); err != nil {
+		t.Fatalf("Failed to add to spellbook: %v", err)
+	}
+	if err := service.PrepareSpell(ctx, "Wiz", "shield", ""); err != nil {
+		t.Errorf("Failed to prepare a spell from the spellbook: %v", err)
+	}
+
+	// Only wizards prepare from a spellbook
+	if err := service.PrepareSpell(ctx, "Cler", "cure wounds", ""); err != nil {
+		t.Errorf("Failed to prepare a cleric spell: %v", err)
+	}
+	if _, err := service.AddToSpellbook(ctx, "Cler", "cure wounds", ""); err == nil {
+		t.Errorf("expected an error adding to a cleric's spellbook")
+	}
+}
+
+func TestPrepareMulticlassSpells(t *testing.T) {
+	newClericWizard := func(name, first string) *domain.Character {
+		abilities := make(map[string]domain.Ability)
+		for ab, score := range map[string]int{"WIS": 12, "INT": 10} {
+			a := domain.Ability{Score: score}
+			a.CalculateModifier()
+			abilities[ab] = a
+		}
+		return &domain.Character{
+			Name:           name,
+			Class:          first,
+			Level:          6,
+			ClassLevels:    map[string]int{"cleric": 3, "wizard": 3},
+			AbilityScores:  abilities,
+			KnownSpells:    make(map[string]domain.Spell),
+			PreparedSpells: make(map[string]domain.Spell),
+		}
+	}
+
+	clericFirst := newClericWizard("ClericFirst", "cleric")
+	wizardFirst := newClericWizard("WizardFirst", "wizard")
+	service := setupServiceWith(clericFirst, wizardFirst)
+	service.AllSpells = map[int][]domain.Spell{
+		1: {
+			{Name: "Shield", Level: 1, Class: []string{"Wizard"}},
+			{Name: "Magic Missile", Level: 1, Class: []string{"Wizard"}},
+			{Name: "Cure Wounds", Level: 1, Class: []string{"Cleric"}},
+			{Name: "Bless", Level: 1, Class: []string{"Cleric"}},
+			{Name: "Guiding Bolt", Level: 1, Class: []string{"Cleric"}},
+		},
+	}
+	ctx := context.Background()
+
+	// A cleric first still needs wizard spells in the spellbook
+	if err := service.PrepareSpell(ctx, "ClericFirst", "shield", ""); err == nil {
+		t.Errorf("expected an error preparing a wizard spell that isn't in the spellbook")
+	}
+	if _, err := service.AddToSpellbook(ctx, "ClericFirst", "shield", ""); err != nil {
+		t.Fatalf("Failed to add to spellbook: %v", err)
+	}
+	if err := service.PrepareSpell(ctx, "ClericFirst", "shield", ""); err != nil {
+		t.Errorf("Failed to prepare a wizard spell from the spellbook: %v", err)
+	}
+
+	// A wizard first prepares cleric spells without a spellbook
+	if err := service.PrepareSpell(ctx, "WizardFirst", "cure wounds", ""); err != nil {
+		t.Errorf("Failed to prepare a cleric spell: %v", err)
+	}
+	if err := service.PrepareSpell(ctx, "WizardFirst", "cure wounds", "wizard"); err == nil {
+		t.Errorf("expected an error preparing a cleric spell as a wizard")
+	}
+
+	// Each class has its own limit: 3/2 + 1 for the cleric, 3/2 + 0 for the wizard
+	if err := service.PrepareSpell(ctx, "WizardFirst", "bless", ""); err != nil {
+		t.Errorf("Failed to prepare a second cleric spell: %v", err)
+	}
+	if err := service.PrepareSpell(ctx, "WizardFirst", "guiding bolt", ""); err == nil {
+		t.Errorf("expected an error past the cleric limit")
+	}
+	for _, spell := range []string{"shield", "magic missile"} {
+		if _, err := service.AddToSpellbook(ctx, "WizardFirst", spell, ""); err != nil {
+			t.Fatalf("Failed to add %s to spellbook: %v", spell, err)
+		}
+	}
+	if err := service.PrepareSpell(ctx, "WizardFirst", "shield", ""); err != nil {
+		t.Errorf("expected the cleric spells not to count against the wizard limit: %v", err)
+	}
+	if err := service.PrepareSpell(ctx, "WizardFirst", "magic missile", ""); err == nil {
+		t.Errorf("expected an error past the wizard limit")
+	}
+
+	char, _ := service.Repo.FindByID(ctx, "WizardFirst")
+	if got := char.PreparedSpellCount("cleric"); got != 2 {
+		t.Errorf("cleric spells prepared = %d, expected 2", got)
+	}
+	if got := char.PreparedSpellCount("wizard"); got != 1 {
+		t.Errorf("wizard spells prepared = %d, expected 1", got)
+	}
+}
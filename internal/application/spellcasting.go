@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// CastSpell casts one of the character's known or prepared spells using a
+// slot of slotLevel (0 for the spell's own level) and records the slot as
+// expended. Casting a concentration spell ends any other one.
+func (s *CharacterService) CastSpell(ctx context.Context, charName, spellName string, slotLevel int) (*domain.Character, *domain.SpellCast, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	char.CalculateMaxSpellSlots()
+	char.UpdateEquipmentProficiencies()
+	s.refreshSpellData(char)
+
+	cast, err := char.CastSpell(spellName, slotLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, nil, fmt.Errorf("failed to save character after casting spell: %w", err)
+	}
+
+	return char, &cast, nil
+}
+
+func (s *CharacterService) ForgetSpell(ctx context.Context, charName, spellName string) error {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := char.ForgetSpell(spellName); err != nil {
+		return err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return fmt.Errorf("failed to save character after forgetting spell: %w", err)
+	}
+
+	return nil
+}
+
+// SwapSpell replaces a known spell with another one from the class list, as
+// allowed once each time a learned caster gains a level. The class defaults
+// to the character's spellcasting class.
+func (s *CharacterService) SwapSpell(ctx context.Context, charName, oldSpell, newSpell, class string) error {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return err
+	}
+
+	spell, ok := s.findSpell(newSpell)
+	if !ok {
+		return fmt.Errorf("spell '%s' not found in SRD spell list", newSpell)
+	}
+
+	if class == "" {
+		class = char.SpellcastingClass()
+	}
+
+	if err := char.SwapSpell(class, oldSpell, spell); err != nil {
+		return err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return fmt.Errorf("failed to save character after swapping spell: %w", err)
+	}
+
+	return nil
+}
+
+// CastRitual casts a ritual spell without expending a spell slot.
+func (s *CharacterService) CastRitual(ctx context.Context, charName, spellName string) (*domain.Character, *domain.SpellCast, error) {
+	char, err := s.Repo.FindByID(ctx, charName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	char.UpdateEquipmentProficiencies()
+	s.refreshSpellData(char)
+
+	cast, err := char.CastRitual(spellName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.Repo.Save(ctx, char); err != nil {
+		return nil, nil, fmt.Errorf("failed to save character after casting ritual: %w", err)
+	}
+
+	return char, &cast, nil
+}
+
+// refreshSpellData updates the character's saved copies of spells with the
+// current spell data, so characters saved before a data update see new
+// fields. Fields filled in by the API are kept when the data lacks them.
+func (s *CharacterService) refreshSpellData(char *domain.Character) {
+	refresh := func(saved domain.Spell) domain.Spell {
+		current, ok := s.findSpell(saved.Name)
+		if !ok {
+			return saved
+		}
+		if current.School == "" {
+			current.School = saved.School
+		}
+		if current.Range == "" {
+			current.Range = saved.Range
+		}
+		return current
+	}
+
+	for key, spell := range char.KnownSpells {
+		char.KnownSpells[key] = refresh(spell)
+	}
+	for key, spell := range char.PreparedSpells {
+		char.PreparedSpells[key] = refresh(spell)
+	}
+	for key, entry := range char.Spellbook {
+		entry.Spell = refresh(entry.Spell)
+		char.Spellbook[key] = entry
+	}
+}
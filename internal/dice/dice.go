@@ -0,0 +1,395 @@
+package dice
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxDiceCount = 100
+	maxDieSides  = 1000
+)
+
+// RNG is the source of randomness for rolls. *rand.Rand satisfies it, and
+// tests can supply a fixed sequence.
+type RNG interface {
+	IntN(n int) int
+}
+
+type Roller struct {
+	rng RNG
+}
+
+func NewRoller(rng RNG) *Roller {
+	return &Roller{rng: rng}
+}
+
+// NewSeededRoller returns a roller that produces the same rolls for the same seed.
+func NewSeededRoller(seed int64) *Roller {
+	return NewRoller(rand.New(rand.NewPCG(uint64(seed), 0)))
+}
+
+func NewRandomRoller() *Roller {
+	return NewSeededRoller(time.Now().UnixNano())
+}
+
+// Term is one part of a dice expression: either NdM with optional keep/drop
+// or advantage modifiers, or a flat number. Sign is +1 or -1.
+type Term struct {
+	Sign         int
+	Count        int
+	Sides        int
+	Constant     int
+	KeepHighest  int
+	KeepLowest   int
+	Advantage    bool
+	Disadvantage bool
+	DamageType   string
+}
+
+func (t Term) IsConstant() bool {
+	return t.Sides == 0
+}
+
+func (t Term) String() string {
+	if t.IsConstant() {
+		return strconv.Itoa(t.Constant)
+	}
+
+	s := fmt.Sprintf("%dd%d", t.Count, t.Sides)
+	switch {
+	case t.Advantage:
+		s += "adv"
+	case t.Disadvantage:
+		s += "dis"
+	case t.KeepHighest > 0:
+		s += fmt.Sprintf("kh%d", t.KeepHighest)
+	case t.KeepLowest > 0:
+		s += fmt.Sprintf("kl%d", t.KeepLowest)
+	}
+
+	return s
+}
+
+type Expression struct {
+	Source string
+	Terms  []Term
+}
+
+// Parse parses expressions such as "2d6+3", "4d6kh3", "1d20adv" or
+// "1d8+1d6 fire". A damage type may follow any term. Supported modifiers are
+// khN/klN (keep highest/lowest), dhN/dlN (drop highest/lowest), adv and dis.
+func Parse(expr string) (Expression, error) {
+	source := strings.TrimSpace(expr)
+	if source == "" {
+		return Expression{}, fmt.Errorf("empty dice expression")
+	}
+
+	var terms []Term
+	sign := 1
+	start := 0
+	lower := strings.ToLower(source)
+
+	for i := 0; i <= len(lower); i++ {
+		if i < len(lower) && lower[i] != '+' && lower[i] != '-' {
+			continue
+		}
+
+		part := strings.TrimSpace(lower[start:i])
+		if part == "" {
+			if i == 0 && i < len(lower) && lower[i] == '-' {
+				sign = -1
+				start = i + 1
+				continue
+			}
+			return Expression{}, fmt.Errorf("invalid dice expression '%s': missing term", expr)
+		}
+
+		term, err := parseTerm(part)
+		if err != nil {
+			return Expression{}, fmt.Errorf("invalid dice expression '%s': %w", expr, err)
+		}
+		term.Sign = sign
+		terms = append(terms, term)
+
+		if i < len(lower) && lower[i] == '-' {
+			sign = -1
+		} else {
+			sign = 1
+		}
+		start = i + 1
+	}
+
+	return Expression{Source: source, Terms: terms}, nil
+}
+
+func parseTerm(part string) (Term, error) {
+	rest := part
+	count, rest := readNumber(rest)
+
+	if !strings.HasPrefix(rest, "d") {
+		if count < 0 {
+			return Term{}, fmt.Errorf("unexpected '%s'", part)
+		}
+
+		term := Term{Constant: count}
+		damageType, err := parseDamageType(rest)
+		if err != nil {
+			return Term{}, err
+		}
+		term.DamageType = damageType
+		return term, nil
+	}
+
+	if count < 0 {
+		count = 1
+	}
+
+	sides, rest := readNumber(rest[1:])
+	if sides < 1 {
+		return Term{}, fmt.Errorf("missing die size in '%s'", part)
+	}
+
+	if count < 1 || count > maxDiceCount {
+		return Term{}, fmt.Errorf("dice count must be between 1 and %d", maxDiceCount)
+	}
+	if sides > maxDieSides {
+		return Term{}, fmt.Errorf("die size must be at most %d", maxDieSides)
+	}
+
+	term := Term{Count: count, Sides: sides}
+
+	switch {
+	case strings.HasPrefix(rest, "adv"):
+		term.Advantage = true
+		rest = rest[3:]
+	case strings.HasPrefix(rest, "dis"):
+		term.Disadvantage = true
+		rest = rest[3:]
+	case strings.HasPrefix(rest, "kh"), strings.HasPrefix(rest, "kl"),
+		strings.HasPrefix(rest, "dh"), strings.HasPrefix(rest, "dl"):
+		modifier := rest[:2]
+		n, remaining := readNumber(rest[2:])
+		if n < 1 || n > count || (modifier[0] == 'd' && n == count) {
+			return Term{}, fmt.Errorf("invalid %s amount in '%s'", modifier, part)
+		}
+		rest = remaining
+
+		switch modifier {
+		case "kh":
+			term.KeepHighest = n
+		case "kl":
+			term.KeepLowest = n
+		case "dh":
+			term.KeepLowest = count - n
+		case "dl":
+			term.KeepHighest = count - n
+		}
+	}
+
+	damageType, err := parseDamageType(rest)
+	if err != nil {
+		return Term{}, err
+	}
+	term.DamageType = damageType
+
+	return term, nil
+}
+
+// readNumber reads leading digits, returning -1 when there are none.
+func readNumber(s string) (int, string) {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+
+	if end == 0 {
+		return -1, s
+	}
+
+	n, err := strconv.Atoi(s[:end])
+	if err != nil {
+		return -1, s
+	}
+
+	return n, s[end:]
+}
+
+func parseDamageType(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && r != ' ' {
+			return "", fmt.Errorf("unexpected '%s'", s)
+		}
+	}
+	return strings.Join(strings.Fields(s), " "), nil
+}
+
+type DieResult struct {
+	Value int
+	Kept  bool
+}
+
+type TermResult struct {
+	Term  Term
+	Dice  []DieResult
+	Total int
+}
+
+type Result struct {
+	Expression string
+	Terms      []TermResult
+	Total      int
+}
+
+// Roll parses and rolls an expression.
+func (r *Roller) Roll(expr string) (Result, error) {
+	parsed, err := Parse(expr)
+	if err != nil {
+		return Result{}, err
+	}
+	return r.RollExpression(parsed), nil
+}
+
+func (r *Roller) RollExpression(expr Expression) Result {
+	result := Result{Expression: expr.Source}
+
+	for _, term := range expr.Terms {
+		termResult := r.rollTerm(term)
+		result.Terms = append(result.Terms, termResult)
+		result.Total += termResult.Total
+	}
+
+	return result
+}
+
+func (r *Roller) rollTerm(term Term) TermResult {
+	result := TermResult{Term: term}
+
+	if term.IsConstant() {
+		result.Total = term.Sign * term.Constant
+		return result
+	}
+
+	rolls := term.Count
+	keepHighest, keepLowest := term.KeepHighest, term.KeepLowest
+
+	// Advantage and disadvantage roll every die twice and keep the better or worse half
+	if term.Advantage {
+		rolls, keepHighest = term.Count*2, term.Count
+	} else if term.Disadvantage {
+		rolls, keepLowest = term.Count*2, term.Count
+	}
+
+	for i := 0; i < rolls; i++ {
+		result.Dice = append(result.Dice, DieResult{Value: r.rng.IntN(term.Sides) + 1, Kept: true})
+	}
+
+	if keepHighest > 0 || keepLowest > 0 {
+		order := make([]int, len(result.Dice))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.SliceStable(order, func(a, b int) bool {
+			return result.Dice[order[a]].Value > result.Dice[order[b]].Value
+		})
+
+		keep := make(map[int]bool)
+		if keepHighest > 0 {
+			for _, idx := range order[:keepHighest] {
+				keep[idx] = true
+			}
+		} else {
+			for _, idx := range order[len(order)-keepLowest:] {
+				keep[idx] = true
+			}
+		}
+
+		for i := range result.Dice {
+			result.Dice[i].Kept = keep[i]
+		}
+	}
+
+	for _, die := range result.Dice {
+		if die.Kept {
+			result.Total += die.Value
+		}
+	}
+	result.Total *= term.Sign
+
+	return result
+}
+
+// TotalsByType sums the result per damage type. A flat modifier without a
+// type counts towards the dice before it, so "1d8 slashing+3" is 1d8+3
+// slashing while "1d8+1d6 fire" keeps the 1d8 untyped.
+func (r Result) TotalsByType() map[string]int {
+	totals := make(map[string]int)
+
+	firstType := ""
+	for _, term := range r.Terms {
+		if term.Term.DamageType != "" {
+			firstType = term.Term.DamageType
+			break
+		}
+	}
+
+	lastDiceType := firstType
+	for _, term := range r.Terms {
+		damageType := term.Term.DamageType
+
+		if !term.Term.IsConstant() {
+			lastDiceType = damageType
+		} else if damageType == "" {
+			damageType = lastDiceType
+		}
+
+		totals[damageType] += term.Total
+	}
+
+	return totals
+}
+
+// String formats the roll with each die shown, dropped dice in parentheses,
+// e.g. "4d6kh3: [5, 4, 3, (1)] = 12".
+func (r Result) String() string {
+	var parts []string
+
+	for i, term := range r.Terms {
+		var part string
+
+		if term.Term.IsConstant() {
+			part = strconv.Itoa(term.Term.Constant)
+		} else {
+			values := make([]string, len(term.Dice))
+			for j, die := range term.Dice {
+				if die.Kept {
+					values[j] = strconv.Itoa(die.Value)
+				} else {
+					values[j] = fmt.Sprintf("(%d)", die.Value)
+				}
+			}
+			part = "[" + strings.Join(values, ", ") + "]"
+		}
+
+		if term.Term.DamageType != "" {
+			part += " " + term.Term.DamageType
+		}
+
+		switch {
+		case term.Term.Sign < 0:
+			part = "- " + part
+		case i > 0:
+			part = "+ " + part
+		}
+
+		parts = append(parts, part)
+	}
+
+	return fmt.Sprintf("%s: %s = %d", r.Expression, strings.Join(parts, " "), r.Total)
+}
@@ -0,0 +1,141 @@
+package dice_test
+
+import (
+	"testing"
+
+	"dnd-char-generator/internal/dice"
+)
+
+// sequenceRNG returns the given die faces in order (IntN returns face-1).
+type sequenceRNG struct {
+	faces []int
+	next  int
+}
+
+func (s *sequenceRNG) IntN(n int) int {
+	face := s.faces[s.next%len(s.faces)]
+	s.next++
+	return face - 1
+}
+
+func TestRoll(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		faces     []int
+		wantTotal int
+		wantKept  []bool
+		wantTypes map[string]int
+	}{
+		{
+			name:      "Dice plus modifier",
+			expr:      "2d6+3",
+			faces:     []int{4, 2},
+			wantTotal: 9,
+		},
+		{
+			name:      "Keep highest three",
+			expr:      "4d6kh3",
+			faces:     []int{5, 1, 4, 3},
+			wantTotal: 12,
+			wantKept:  []bool{true, false, true, true},
+		},
+		{
+			name:      "Drop lowest is keep highest",
+			expr:      "4d6dl1",
+			faces:     []int{2, 6, 6, 1},
+			wantTotal: 14,
+			wantKept:  []bool{true, true, true, false},
+		},
+		{
+			name:      "Advantage keeps the higher d20",
+			expr:      "1d20adv",
+			faces:     []int{7, 15},
+			wantTotal: 15,
+			wantKept:  []bool{false, true},
+		},
+		{
+			name:      "Disadvantage keeps the lower d20",
+			expr:      "1d20dis+5",
+			faces:     []int{7, 15},
+			wantTotal: 12,
+			wantKept:  []bool{true, false},
+		},
+		{
+			name:      "Mixed damage types",
+			expr:      "1d8+1d6 fire",
+			faces:     []int{8, 3},
+			wantTotal: 11,
+			wantTypes: map[string]int{"": 8, "fire": 3},
+		},
+		{
+			name:      "Untyped modifier joins the typed dice",
+			expr:      "1d8 slashing + 2",
+			faces:     []int{5},
+			wantTotal: 7,
+			wantTypes: map[string]int{"slashing": 7},
+		},
+		{
+			name:      "Subtraction",
+			expr:      "1d4-1",
+			faces:     []int{1},
+			wantTotal: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roller := dice.NewRoller(&sequenceRNG{faces: tt.faces})
+
+			result, err := roller.Roll(tt.expr)
+			if err != nil {
+				t.Fatalf("Roll(%q) failed: %v", tt.expr, err)
+			}
+
+			if result.Total != tt.wantTotal {
+				t.Errorf("Roll(%q) total = %d, expected %d (%s)", tt.expr, result.Total, tt.wantTotal, result)
+			}
+
+			if tt.wantKept != nil {
+				dieResults := result.Terms[0].Dice
+				if len(dieResults) != len(tt.wantKept) {
+					t.Fatalf("Roll(%q) rolled %d dice, expected %d", tt.expr, len(dieResults), len(tt.wantKept))
+				}
+				for i, kept := range tt.wantKept {
+					if dieResults[i].Kept != kept {
+						t.Errorf("Roll(%q) die %d kept = %t, expected %t", tt.expr, i, dieResults[i].Kept, kept)
+					}
+				}
+			}
+
+			if tt.wantTypes != nil {
+				totals := result.TotalsByType()
+				for damageType, want := range tt.wantTypes {
+					if totals[damageType] != want {
+						t.Errorf("Roll(%q) %q damage = %d, expected %d", tt.expr, damageType, totals[damageType], want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "d", "2d", "1d6+", "4d6kh5", "3d6dl3", "1d6 +fire!", "1000d6"} {
+		if _, err := dice.Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected an error", expr)
+		}
+	}
+}
+
+func TestSeededRollerIsDeterministic(t *testing.T) {
+	first, err := dice.NewSeededRoller(42).Roll("10d20")
+	if err != nil {
+		t.Fatalf("Roll failed: %v", err)
+	}
+
+	second, _ := dice.NewSeededRoller(42).Roll("10d20")
+	if first.String() != second.String() {
+		t.Errorf("same seed produced different rolls:\n%s\n%s", first, second)
+	}
+}
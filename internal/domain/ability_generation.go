@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"dnd-char-generator/internal/dice"
+)
+
+var abilityOrder = []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"}
+
+var StandardArray = []int{15, 14, 13, 12, 10, 8}
+
+const PointBuyBudget = 27
+
+var PointBuyCosts = map[int]int{8: 0, 9: 1, 10: 2, 11: 3, 12: 4, 13: 5, 14: 7, 15: 9}
+
+// AbilityScoreMethod validates the six base ability scores (before racial
+// bonuses) produced by one of the generation methods.
+type AbilityScoreMethod interface {
+	Name() string
+	Validate(scores map[string]int) error
+}
+
+// AbilityScoreMethods maps a method key to its constructor. Only the rolled
+// method uses the seed.
+var AbilityScoreMethods = map[string]func(seed int64) AbilityScoreMethod{
+	"standard": func(int64) AbilityScoreMethod { return StandardArrayMethod{} },
+	"pointbuy": func(int64) AbilityScoreMethod { return PointBuyMethod{} },
+	"rolled":   func(seed int64) AbilityScoreMethod { return RolledMethod{Seed: seed} },
+}
+
+// NewAbilityScoreMethod looks up a generation method by name, defaulting to
+// the standard array. Dashes and spaces are ignored, so "point-buy" works.
+func NewAbilityScoreMethod(name string, seed int64) (AbilityScoreMethod, error) {
+	key := strings.ToLower(name)
+	key = strings.NewReplacer("-", "", "_", "", " ", "").Replace(key)
+	if key == "" {
+		key = "standard"
+	}
+
+	constructor, ok := AbilityScoreMethods[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown ability score method '%s' (use standard, point-buy or rolled)", name)
+	}
+
+	return constructor(seed), nil
+}
+
+type StandardArrayMethod struct{}
+
+func (StandardArrayMethod) Name() string { return "standard array" }
+
+func (StandardArrayMethod) Validate(scores map[string]int) error {
+	if err := checkAbilityKeys(scores); err != nil {
+		return err
+	}
+
+	if !sameScores(scores, StandardArray) {
+		return fmt.Errorf("the standard array assigns each of %s exactly once", formatScores(StandardArray))
+	}
+
+	return nil
+}
+
+type PointBuyMethod struct{}
+
+func (PointBuyMethod) Name() string { return "point buy" }
+
+func (PointBuyMethod) Validate(scores map[string]int) error {
+	if err := checkAbilityKeys(scores); err != nil {
+		return err
+	}
+
+	spent := 0
+	for _, ab := range abilityOrder {
+		cost, ok := PointBuyCosts[scores[ab]]
+		if !ok {
+			return fmt.Errorf("point buy scores must be between 8 and 15, %s is %d", ab, scores[ab])
+		}
+		spent += cost
+	}
+
+	if spent > PointBuyBudget {
+		return fmt.Errorf("point buy spent %d points, the budget is %d", spent, PointBuyBudget)
+	}
+
+	return nil
+}
+
+// RolledMethod checks the scores are an assignment of the six 4d6-drop-lowest
+// rolls produced by the recorded seed.
+type RolledMethod struct {
+	Seed int64
+}
+
+func (RolledMethod) Name() string { return "rolled" }
+
+func (m RolledMethod) Validate(scores map[string]int) error {
+	if err := checkAbilityKeys(scores); err != nil {
+		return err
+	}
+
+	rolled := RollAbilityScores(m.Seed)
+	if !sameScores(scores, rolled) {
+		return fmt.Errorf("scores don't match the rolls for seed %d: %s", m.Seed, formatScores(rolled))
+	}
+
+	return nil
+}
+
+// AbilityScoreRoll is rolled for each score: 4d6, dropping the lowest die.
+const AbilityScoreRoll = "4d6kh3"
+
+// RollAbilityScores rolls six scores with 4d6, dropping the lowest die of each.
+// The same seed always produces the same scores.
+func RollAbilityScores(seed int64) []int {
+	roller := dice.NewSeededRoller(seed)
+
+	// The expression is a constant, so it always parses
+	expr, _ := dice.Parse(AbilityScoreRoll)
+
+	scores := make([]int, 0, len(abilityOrder))
+	for range abilityOrder {
+		scores = append(scores, roller.RollExpression(expr).Total)
+	}
+
+	return scores
+}
+
+// AssignInOrder maps scores to STR, DEX, CON, INT, WIS, CHA in that order.
+func AssignInOrder(scores []int) map[string]int {
+	assignments := make(map[string]int)
+	for i, ab := range abilityOrder {
+		if i < len(scores) {
+			assignments[ab] = scores[i]
+		}
+	}
+	return assignments
+}
+
+func checkAbilityKeys(scores map[string]int) error {
+	if len(scores) != len(abilityOrder) {
+		return fmt.Errorf("must provide 6 ability scores")
+	}
+
+	for _, ab := range abilityOrder {
+		if _, ok := scores[ab]; !ok {
+			return fmt.Errorf("missing ability score for %s", ab)
+		}
+	}
+
+	return nil
+}
+
+func sameScores(scores map[string]int, expected []int) bool {
+	var actual []int
+	for _, ab := range abilityOrder {
+		actual = append(actual, scores[ab])
+	}
+
+	want := append([]int(nil), expected...)
+	sort.Ints(actual)
+	sort.Ints(want)
+
+	for i := range want {
+		if actual[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatScores(scores []int) string {
+	parts := make([]string, len(scores))
+	for i, score := range scores {
+		parts[i] = fmt.Sprint(score)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"slices"
+	"testing"
+)
+
+// Saved characters keep their AbilityScoreSeed, so a seed has to keep
+// producing the same scores.
+func TestRollAbilityScoresSeeds(t *testing.T) {
+	tests := []struct {
+		seed int64
+		want []int
+	}{
+		{seed: 1, want: []int{10, 14, 6, 14, 14, 12}},
+		{seed: 7, want: []int{6, 17, 13, 12, 12, 14}},
+		{seed: 42, want: []int{13, 15, 14, 11, 11, 11}},
+	}
+
+	for _, tt := range tests {
+		if got := domain.RollAbilityScores(tt.seed); !slices.Equal(got, tt.want) {
+			t.Errorf("RollAbilityScores(%d) = %v, expected %v", tt.seed, got, tt.want)
+		}
+	}
+}
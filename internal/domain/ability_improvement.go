@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const MaxAbilityScore = 20
+
+// AbilityScoreImprovement records the choice made at an ASI level so it can
+// be undone if the character loses that level.
+type AbilityScoreImprovement struct {
+	Class      string
+	ClassLevel int
+	Increases  map[string]int
+	Feat       string
+	FeatChoice string
+}
+
+func IsASILevel(class string, classLevel int) bool {
+	levels, ok := ASILevels[strings.ToLower(class)]
+	if !ok {
+		levels = ASILevels["default"]
+	}
+
+	for _, lvl := range levels {
+		if lvl == classLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyAbilityScoreImprovement applies the ASI gained at the given class
+// level: either +2 to one ability or +1 to two abilities, or a feat instead.
+// featAbility picks the ability for feats that let the player choose one.
+// The 20 cap and feat prerequisites use the scores without magic items.
+func (c *Character) ApplyAbilityScoreImprovement(class string, classLevel int, increases map[string]int, feat, featAbility string) error {
+	if len(increases) > 0 && feat != "" {
+		return fmt.Errorf("choose either ability score increases or a feat, not both")
+	}
+
+	if len(increases) == 0 && feat == "" {
+		return fmt.Errorf("%s level %d grants an Ability Score Improvement: choose ability score increases or a feat",
+			strings.ToLower(class), classLevel)
+	}
+
+	record := AbilityScoreImprovement{
+		Class:      strings.ToLower(class),
+		ClassLevel: classLevel,
+		Increases:  make(map[string]int),
+	}
+
+	if feat != "" {
+		featName := strings.ToLower(strings.TrimSpace(feat))
+		data, ok := AllFeats[featName]
+		if !ok {
+			return fmt.Errorf("feat '%s' not found", feat)
+		}
+
+		if c.HasFeat(featName) {
+			return fmt.Errorf("character '%s' already has the %s feat", c.Name, featName)
+		}
+
+		// Magic items don't count towards feat prerequisites
+		for ab, minScore := range data.Prerequisites {
+			if c.BaseAbilityScore(ab) < minScore {
+				return fmt.Errorf("the %s feat requires %s %d", featName, ab, minScore)
+			}
+		}
+
+		for ab, bonus := range data.AbilityIncreases {
+			record.Increases[ab] += bonus
+		}
+
+		if len(data.AbilityChoices) > 0 {
+			chosen := strings.ToUpper(strings.TrimSpace(featAbility))
+			valid := false
+			for _, ab := range data.AbilityChoices {
+				if ab == chosen {
+					valid = true
+					break
+				}
+			}
+
+			if !valid {
+				return fmt.Errorf("the %s feat increases one of %s: choose which", featName, strings.Join(data.AbilityChoices, ", "))
+			}
+			record.Increases[chosen]++
+			record.FeatChoice = chosen
+		}
+
+		// A feat's increase stops at 20 instead of ruling the feat out
+		for ab, amount := range record.Increases {
+			if capped := min(amount, MaxAbilityScore-c.BaseAbilityScore(ab)); capped > 0 {
+				record.Increases[ab] = capped
+			} else {
+				delete(record.Increases, ab)
+			}
+		}
+
+		record.Feat = featName
+	} else {
+		total := 0
+		for ab, amount := range increases {
+			if _, ok := AllAbilities[ab]; !ok {
+				return fmt.Errorf("unknown ability '%s'", ab)
+			}
+			if amount < 1 || amount > 2 {
+				return fmt.Errorf("an Ability Score Improvement gives +2 to one ability or +1 to two")
+			}
+			total += amount
+			record.Increases[ab] = amount
+		}
+
+		if total != 2 {
+			return fmt.Errorf("an Ability Score Improvement gives +2 to one ability or +1 to two")
+		}
+	}
+
+	for ab, amount := range record.Increases {
+		if c.BaseAbilityScore(ab)+amount > MaxAbilityScore {
+			return fmt.Errorf("%s can't be increased above %d", ab, MaxAbilityScore)
+		}
+	}
+
+	for ab, amount := range record.Increases {
+		c.adjustAbilityScore(ab, amount)
+	}
+	// Items setting a score are re-applied over the new base score
+	c.UpdateItemAbilityScores()
+
+	if record.Feat != "" {
+		c.Feats = append(c.Feats, record.Feat)
+
+		if AllFeats[record.Feat].SaveProficiencyInChoice {
+			if c.SavingThrowProficiencies == nil {
+				c.SavingThrowProficiencies = make(map[string]bool)
+			}
+			c.SavingThrowProficiencies[record.FeatChoice] = true
+		}
+	}
+	c.AbilityScoreImprovements = append(c.AbilityScoreImprovements, record)
+
+	return nil
+}
+
+// RemoveImprovementsAbove undoes the ASIs taken above the given class level,
+// used when a character's level is lowered.
+func (c *Character) RemoveImprovementsAbove(class string, classLevel int) {
+	className := strings.ToLower(class)
+
+	var kept []AbilityScoreImprovement
+	for _, record := range c.AbilityScoreImprovements {
+		if record.Class != className || record.ClassLevel <= classLevel {
+			kept = append(kept, record)
+			continue
+		}
+
+		for ab, amount := range record.Increases {
+			c.adjustAbilityScore(ab, -amount)
+		}
+
+		if record.Feat != "" {
+			c.removeFeat(record.Feat)
+
+			if AllFeats[record.Feat].SaveProficiencyInChoice {
+				delete(c.SavingThrowProficiencies, record.FeatChoice)
+				c.SetClassSavingThrows()
+			}
+		}
+	}
+
+	c.AbilityScoreImprovements = kept
+	c.UpdateItemAbilityScores()
+}
+
+// PendingASILevels lists the ASI levels of a class between two class levels
+// (exclusive of from, inclusive of to).
+func PendingASILevels(class string, from, to int) []int {
+	var pending []int
+	for lvl := from + 1; lvl <= to; lvl++ {
+		if IsASILevel(class, lvl) {
+			pending = append(pending, lvl)
+		}
+	}
+	return pending
+}
+
+func (c *Character) HasFeat(feat string) bool {
+	for _, f := range c.Feats {
+		if strings.EqualFold(f, feat) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Character) SortedFeats() []string {
+	feats := append([]string(nil), c.Feats...)
+	sort.Strings(feats)
+	return feats
+}
+
+func (c *Character) removeFeat(feat string) {
+	var kept []string
+	for _, f := range c.Feats {
+		if !strings.EqualFold(f, feat) {
+			kept = append(kept, f)
+		}
+	}
+	c.Feats = kept
+}
+
+func (c *Character) adjustAbilityScore(ab string, amount int) {
+	ability := c.AbilityScores[ab]
+	ability.Score += amount
+	ability.CalculateModifier()
+	c.AbilityScores[ab] = ability
+}
+
+// hasFeatEffect reports whether any of the character's feats has the effect.
+func (c *Character) hasFeatEffect(effect func(FeatData) bool) bool {
+	for _, feat := range c.Feats {
+		if data, ok := AllFeats[feat]; ok && effect(data) {
+			return true
+		}
+	}
+	return false
+}
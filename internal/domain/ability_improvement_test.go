@@ -0,0 +1,175 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestAbilityScoreImprovement(t *testing.T) {
+	newChar := func() *domain.Character {
+		abilities := make(map[string]domain.Ability)
+		for ab, score := range map[string]int{"STR": 12, "DEX": 14, "CON": 13, "INT": 10, "WIS": 19, "CHA": 8} {
+			a := domain.Ability{Score: score}
+			a.CalculateModifier()
+			abilities[ab] = a
+		}
+		return &domain.Character{
+			Name:                     "Improving",
+			Class:                    "cleric",
+			AbilityScores:            abilities,
+			SavingThrowProficiencies: map[string]bool{"WIS": true, "CHA": true},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		increases   map[string]int
+		feat        string
+		featAbility string
+		wantErr     bool
+		wantScores  map[string]int
+		wantSave    string
+	}{
+		{name: "PlusTwo", increases: map[string]int{"DEX": 2}, wantScores: map[string]int{"DEX": 16}},
+		{name: "PlusOneEach", increases: map[string]int{"STR": 1, "CON": 1}, wantScores: map[string]int{"STR": 13, "CON": 14}},
+		{name: "OnlyPlusOne", increases: map[string]int{"STR": 1}, wantErr: true},
+		{name: "PlusThree", increases: map[string]int{"STR": 3}, wantErr: true},
+		{name: "AboveMaximum", increases: map[string]int{"WIS": 2}, wantErr: true},
+		{name: "NoChoice", wantErr: true},
+		{name: "BothChoices", increases: map[string]int{"DEX": 2}, feat: "alert", wantErr: true},
+		{name: "Feat", feat: "Alert", wantScores: map[string]int{"DEX": 14}},
+		{name: "UnknownFeat", feat: "lightning reflexes", wantErr: true},
+		{name: "FeatPrerequisite", feat: "grappler", wantErr: true},
+		{name: "FeatIncrease", feat: "keen mind", wantScores: map[string]int{"INT": 11}},
+		{name: "FeatChoiceMissing", feat: "resilient", wantErr: true},
+		{name: "FeatChoice", feat: "resilient", featAbility: "con", wantScores: map[string]int{"CON": 14}, wantSave: "CON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newChar()
+
+			err := char.ApplyAbilityScoreImprovement("cleric", 4, tt.increases, tt.feat, tt.featAbility)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyAbilityScoreImprovement error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(char.AbilityScoreImprovements) != 0 || len(char.Feats) != 0 {
+					t.Errorf("expected a rejected choice to change nothing")
+				}
+				return
+			}
+
+			for ab, want := range tt.wantScores {
+				if got := char.AbilityScores[ab].Score; got != want {
+					t.Errorf("%s = %d, expected %d", ab, got, want)
+				}
+			}
+			if tt.feat != "" && !char.HasFeat(tt.feat) {
+				t.Errorf("expected the %s feat, got %v", tt.feat, char.Feats)
+			}
+			if tt.wantSave != "" && !char.SavingThrowProficiencies[tt.wantSave] {
+				t.Errorf("expected proficiency in %s saves", tt.wantSave)
+			}
+		})
+	}
+
+	t.Run("FeatOnlyOnce", func(t *testing.T) {
+		char := newChar()
+		if err := char.ApplyAbilityScoreImprovement("cleric", 4, nil, "alert", ""); err != nil {
+			t.Fatalf("Failed to take feat: %v", err)
+		}
+		if err := char.ApplyAbilityScoreImprovement("cleric", 8, nil, "alert", ""); err == nil {
+			t.Errorf("expected an error taking the same feat twice")
+		}
+	})
+
+	t.Run("IgnoresItemBonuses", func(t *testing.T) {
+		char := newChar()
+		char.MagicItems = []domain.MagicItem{{
+			Name:               "gauntlets of ogre power",
+			RequiresAttunement: true,
+			Attuned:            true,
+			Modifiers:          []domain.ItemModifier{{Target: domain.StatAbility, Ability: "STR", Value: 19, Set: true}},
+		}}
+		char.UpdateItemAbilityScores()
+
+		// STR 19 from the gauntlets doesn't meet grappler's STR 13 for a base of 12
+		if err := char.ApplyAbilityScoreImprovement("cleric", 4, nil, "grappler", ""); err == nil {
+			t.Errorf("expected the gauntlets not to meet the grappler prerequisite")
+		}
+
+		if err := char.ApplyAbilityScoreImprovement("cleric", 4, map[string]int{"STR": 2}, "", ""); err != nil {
+			t.Fatalf("Failed to raise base STR under the gauntlets: %v", err)
+		}
+		if base, got := char.BaseAbilityScore("STR"), char.AbilityScores["STR"].Score; base != 14 || got != 19 {
+			t.Errorf("STR = %d (base %d), expected 19 (base 14)", got, base)
+		}
+
+		char.RemoveImprovementsAbove("cleric", 3)
+		if base, got := char.BaseAbilityScore("STR"), char.AbilityScores["STR"].Score; base != 12 || got != 19 {
+			t.Errorf("STR after removing the ASI = %d (base %d), expected 19 (base 12)", got, base)
+		}
+	})
+
+	t.Run("FeatIncreaseCapped", func(t *testing.T) {
+		char := newChar()
+		char.AbilityScores["WIS"] = domain.Ability{Score: 20, Modifier: 5}
+
+		if err := char.ApplyAbilityScoreImprovement("cleric", 4, nil, "resilient", "WIS"); err != nil {
+			t.Fatalf("Failed to take a feat with a capped increase: %v", err)
+		}
+		if got := char.AbilityScores["WIS"].Score; got != 20 {
+			t.Errorf("WIS = %d, expected it to stay at 20", got)
+		}
+		if !char.HasFeat("resilient") {
+			t.Errorf("expected the resilient feat, got %v", char.Feats)
+		}
+
+		// Undoing the feat doesn't take away the increase it never gave
+		char.RemoveImprovementsAbove("cleric", 3)
+		if got := char.AbilityScores["WIS"].Score; got != 20 {
+			t.Errorf("WIS after removing the feat = %d, expected 20", got)
+		}
+	})
+
+	t.Run("RemovedWithLevel", func(t *testing.T) {
+		char := newChar()
+		if err := char.ApplyAbilityScoreImprovement("cleric", 4, map[string]int{"DEX": 2}, "", ""); err != nil {
+			t.Fatalf("Failed to apply ASI: %v", err)
+		}
+		if err := char.ApplyAbilityScoreImprovement("cleric", 8, nil, "resilient", "CON"); err != nil {
+			t.Fatalf("Failed to take feat: %v", err)
+		}
+
+		char.RemoveImprovementsAbove("cleric", 7)
+		if char.HasFeat("resilient") || char.AbilityScores["CON"].Score != 13 || char.SavingThrowProficiencies["CON"] {
+			t.Errorf("expected the level 8 feat to be undone, got feats %v, CON %d", char.Feats, char.AbilityScores["CON"].Score)
+		}
+		if got := char.AbilityScores["DEX"].Score; got != 16 {
+			t.Errorf("DEX = %d, expected the level 4 increase to stay", got)
+		}
+	})
+}
+
+func TestPendingASILevels(t *testing.T) {
+	tests := []struct {
+		class string
+		from  int
+		to    int
+		want  []int
+	}{
+		{class: "wizard", from: 0, to: 3},
+		{class: "wizard", from: 0, to: 8, want: []int{4, 8}},
+		{class: "wizard", from: 4, to: 8, want: []int{8}},
+		{class: "fighter", from: 0, to: 6, want: []int{4, 6}},
+		{class: "rogue", from: 8, to: 10, want: []int{10}},
+	}
+
+	for _, tt := range tests {
+		if got := domain.PendingASILevels(tt.class, tt.from, tt.to); !slices.Equal(got, tt.want) {
+			t.Errorf("PendingASILevels(%s, %d, %d) = %v, expected %v", tt.class, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type WeaponAttack struct {
+	Weapon      string
+	Hand        string
+	Ability     string
+	Proficient  bool
+	AttackBonus int
+	DamageDice  string
+	DamageBonus int
+	DamageType  string
+
+	// TwoHanded is set when a versatile weapon is wielded with both hands.
+	TwoHanded bool
+
+	// ToHit and DamageModifier explain AttackBonus and DamageBonus.
+	ToHit          Breakdown
+	DamageModifier Breakdown
+}
+
+// AttackExpression is the d20 roll for the attack, e.g. "1d20+5", or
+// "1d20dis+5" with disadvantage.
+func (a WeaponAttack) AttackExpression() string {
+	return d20Expression(a.AttackBonus, a.ToHit.HasAdvantage(), a.ToHit.HasDisadvantage())
+}
+
+// DamageExpression is the damage roll, e.g. "1d8+3 slashing".
+func (a WeaponAttack) DamageExpression() string {
+	expr := a.DamageDice
+	if a.DamageBonus != 0 {
+		expr += fmt.Sprintf("%+d", a.DamageBonus)
+	}
+	if a.DamageType != "" {
+		expr += " " + a.DamageType
+	}
+	return expr
+}
+
+// IsProficientWithWeapon checks the weapon's category and name against the
+// character's weapon proficiencies.
+func (c *Character) IsProficientWithWeapon(weapon Weapon) bool {
+	if category := weapon.WeaponCategory(); category != "" && c.WeaponProficiencies[category] {
+		return true
+	}
+	return c.WeaponProficiencies[strings.ToLower(weapon.Name)] || c.WeaponProficiencies[strings.ToLower(weapon.Base)]
+}
+
+// GetWeaponAttack computes the attack bonus and damage of the weapon in the
+// given hand ("main" or "off"). Ranged weapons use DEX, finesse weapons the
+// better of STR and DEX, everything else STR. The off-hand attack only adds
+// the ability modifier to damage when it is negative, and needs weapons that
+// qualify for two-weapon fighting in both hands. A versatile weapon uses its
+// two-handed damage while the off hand is free.
+func (c *Character) GetWeaponAttack(hand string) (WeaponAttack, error) {
+	var weapon Weapon
+	var slot string
+
+	switch strings.ToLower(strings.TrimSpace(hand)) {
+	case "main", "main hand":
+		weapon, slot = c.EquippedWeaponMainHand, "main hand"
+	case "off", "off hand":
+		weapon, slot = c.EquippedWeaponOffHand, "off hand"
+	default:
+		return WeaponAttack{}, fmt.Errorf("invalid hand '%s'. Must be 'main' or 'off'", hand)
+	}
+
+	if weapon.Name == "" {
+		return WeaponAttack{}, fmt.Errorf("no weapon equipped in %s", slot)
+	}
+
+	if weapon.Damage == "" {
+		return WeaponAttack{}, fmt.Errorf("weapon '%s' has no damage data", weapon.Name)
+	}
+
+	if slot == "off hand" {
+		if c.EquippedWeaponMainHand.Name == "" {
+			return WeaponAttack{}, fmt.Errorf("an off hand attack needs a weapon in the main hand too")
+		}
+		if err := c.CheckTwoWeaponFighting(c.EquippedWeaponMainHand, weapon); err != nil {
+			return WeaponAttack{}, err
+		}
+	}
+
+	strMod := c.AbilityScores["STR"].Modifier
+	dexMod := c.AbilityScores["DEX"].Modifier
+
+	ability, mod := "STR", strMod
+	if weapon.IsRanged() || (weapon.HasProperty("finesse") && dexMod > strMod) {
+		ability, mod = "DEX", dexMod
+	}
+
+	abilityMod := []Contribution{{Source: ability, Value: mod}}
+	damageMod := abilityMod
+	if slot == "off hand" && mod > 0 {
+		damageMod = nil
+	}
+
+	attack := WeaponAttack{
+		Weapon:         weapon.Name,
+		Hand:           slot,
+		Ability:        ability,
+		ToHit:          c.fold(weapon.Name+" attack", matching(StatAttack, weapon.Name), c.IsProficientWithWeapon(weapon), abilityMod...),
+		DamageModifier: c.fold(weapon.Name+" damage", matching(StatDamage, weapon.Name), false, damageMod...),
+	}
+	attack.Proficient = attack.ToHit.Proficient
+	attack.AttackBonus = attack.ToHit.Total
+	attack.DamageBonus = attack.DamageModifier.Total
+
+	fields := strings.Fields(weapon.Damage)
+	attack.DamageDice = fields[0]
+	attack.DamageType = strings.ToLower(strings.Join(fields[1:], " "))
+
+	// Versatile weapons deal their bigger damage die when held in both hands
+	if slot == "main hand" && weapon.VersatileDamage != "" && c.OffHandFree() {
+		attack.DamageDice = weapon.VersatileDamage
+		attack.TwoHanded = true
+	}
+
+	return attack, nil
+}
+
+// MainHandAttack returns the main-hand attack, or an empty attack if there
+// is no usable weapon in that hand.
+func (c *Character) MainHandAttack() WeaponAttack {
+	attack, _ := c.GetWeaponAttack("main")
+	return attack
+}
+
+func (c *Character) OffHandAttack() WeaponAttack {
+	attack, _ := c.GetWeaponAttack("off")
+	return attack
+}
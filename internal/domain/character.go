@@ -40,6 +40,8 @@ type Character struct {
 	SpellcasterType  SpellcasterType
 	Background       string
 	Level            int
+	ClassLevels      map[string]int
+	Subclasses       map[string]string
 	ProficiencyBonus int
 
 	MaxHitPoints      int
@@ -47,6 +49,19 @@ type Character struct {
 	ArmorClass        int
 	Initiative        int
 	PassivePerception int
+	Speed             int
+	Encumbrance       Encumbrance
+
+	TemporaryHitPoints int
+	DeathSaveSuccesses int
+	DeathSaveFailures  int
+	Stable             bool
+	Dead               bool
+	Conditions         map[string]Condition
+	HitDiceSpent       map[int]int
+
+	AbilityScoreMethod string
+	AbilityScoreSeed   int64
 
 	AbilityScores          map[string]Ability
 	SkillProficiencies     map[string]bool
@@ -55,10 +70,35 @@ type Character struct {
 	EquippedWeaponOffHand  Weapon
 	EquippedArmor          Armor
 	EquippedShield         Shield
+	Inventory              []InventoryItem
+	MagicItems             []MagicItem
+	Purse                  Purse
 	KnownSpells            map[string]Spell
 	PreparedSpells         map[string]Spell
+	Spellbook              map[string]SpellbookEntry
+	LastSpellSwapLevels    map[string]int
+
+	SavingThrowProficiencies map[string]bool
+	WeaponProficiencies      ProficiencySet
+	ArmorProficiencies       ProficiencySet
+	ToolProficiencies        ProficiencySet
+
+	// ItemAbilityBonuses are the magic item bonuses included in AbilityScores
+	ItemAbilityBonuses map[string]int
+
+	// KnownSpellClasses and PreparedSpellClasses record which class each
+	// known or prepared spell is for
+	KnownSpellClasses    map[string]string
+	PreparedSpellClasses map[string]string
+
+	Features                 map[string]CharacterFeature
+	AbilityScoreImprovements []AbilityScoreImprovement
+	Feats                    []string
 
 	MaxSpellSlots       map[int]int
+	ExpendedSpellSlots  map[int]int
+	ExpendedPactSlots   int
+	ConcentratingOn     string
 	SpellCastingAbility string
 	SpellSaveDC         int
 	SpellAttackBonus    int
@@ -66,7 +106,7 @@ type Character struct {
 
 func NewCharacter(name, race, class, background string, scoreAssignments map[string]int) (*Character, error) {
 	if len(scoreAssignments) != 6 {
-		return nil, fmt.Errorf("must provide 6 ability scores using the Standard Array")
+		return nil, fmt.Errorf("must provide 6 ability scores")
 	}
 
 	char := &Character{
@@ -75,12 +115,15 @@ func NewCharacter(name, race, class, background string, scoreAssignments map[str
 		Class:              class,
 		Background:         background,
 		Level:              1,
+		ClassLevels:        map[string]int{strings.ToLower(class): 1},
+		Subclasses:         make(map[string]string),
 		AbilityScores:      make(map[string]Ability),
 		MaxSpellSlots:      make(map[int]int),
 		SkillProficiencies: make(map[string]bool),
 		SkillExpertise:     make(map[string]bool),
 		KnownSpells:        make(map[string]Spell),
 		PreparedSpells:     make(map[string]Spell),
+		Features:           make(map[string]CharacterFeature),
 	}
 
 	if data, ok := AllClassesData[strings.ToLower(class)]; ok {
@@ -113,6 +156,9 @@ func NewCharacter(name, race, class, background string, scoreAssignments map[str
 		char.SkillProficiencies[skill] = false
 	}
 
+	char.SetClassSavingThrows()
+	char.UpdateEquipmentProficiencies()
+
 	char.UpdateProficiencyBonus(1)
 	return char, nil
 }
@@ -140,19 +186,11 @@ func (c *Character) GetSkillModifier(skill string) int {
 		return 0
 	}
 
-	ability, ok := c.AbilityScores[abilityKey]
-	if !ok {
+	if _, ok := c.AbilityScores[abilityKey]; !ok {
 		return 0
 	}
 
-	modifier := ability.Modifier
-	isProficient := c.SkillProficiencies[skill]
-
-	if isProficient {
-		modifier += c.ProficiencyBonus
-	}
-
-	return modifier
+	return c.SkillBreakdown(skill).Total
 }
 
 func (c *Character) GetAbilityForSkill(skillName string) string {
@@ -164,148 +202,146 @@ func (c *Character) GetAbilityForSkill(skillName string) string {
 
 func (c *Character) CalculateMaxSpellSlots() {
 	c.MaxSpellSlots = make(map[int]int)
-	classLower := strings.ToLower(c.Class)
-
-	var slots map[int]int
-	var cantripProgression map[int]int
+	levels := c.ClassLevelBreakdown()
 
-	switch classLower {
-	case "wizard", "sorcerer", "bard", "cleric", "druid":
-		slots = FullCasterSlots[c.Level]
-		cantripProgression = CantripCount["default_full"]
+	var casterClasses []string
+	for class := range levels {
+		switch AllClassesData[class].CasterProgression {
+		case FullCaster, HalfCaster:
+			casterClasses = append(casterClasses, class)
+		}
+	}
 
-	case "paladin", "ranger":
-		slots = HalfCasterSlots[c.Level]
-		cantripProgression = nil
+	var slots map[int]int
 
-	case "warlock":
-		slots = PactCasterSlots[c.Level]
-		cantripProgression = CantripCount["warlock"]
+	switch {
+	case len(casterClasses) == 1:
+		// A single spellcasting class uses its own table
+		class := casterClasses[0]
+		if AllClassesData[class].CasterProgression == HalfCaster {
+			slots = HalfCasterSlots[levels[class]]
+		} else {
+			slots = FullCasterSlots[levels[class]]
+		}
 
-	default:
-		// Non-casters
-		return
+	case len(casterClasses) > 1:
+		// The multiclass spellcaster table is the full caster table indexed by
+		// the combined caster level
+		slots = FullCasterSlots[c.casterLevel()]
 	}
 
 	for level, count := range slots {
-		c.MaxSpellSlots[level] = count
+		c.MaxSpellSlots[level] += count
 	}
 
-	if cantripProgression != nil {
-		maxLvl := 0
-		maxCount := 0
-
-		for lvl, count := range cantripProgression {
-			if c.Level >= lvl {
-				if lvl > maxLvl {
-					maxLvl = lvl
-					maxCount = count
-				}
-			}
+	// Pact Magic slots stack with slots from the Spellcasting feature
+	for class, lvl := range levels {
+		if AllClassesData[class].CasterProgression != PactCaster {
+			continue
 		}
 
-		if maxCount > 0 {
-			c.MaxSpellSlots[0] = maxCount
+		for level, count := range PactCasterSlots[lvl] {
+			c.MaxSpellSlots[level] += count
 		}
 	}
-}
 
-func (c *Character) CalculateMaxHitPoints() {
-	hitDie := 6
-	hitDieAvg := 4
-	switch c.Class {
-	case "Fighter", "Paladin":
-		hitDie = 10
-		hitDieAvg = 6
-	case "Cleric", "Druid", "Ranger", "Rogue", "Bard", "Warlock":
-		hitDie = 8
-		hitDieAvg = 5
-	case "Sorcerer":
-		hitDie = 6
-		hitDieAvg = 4
+	cantrips := 0
+	for class, lvl := range levels {
+		if progression, ok := CantripCount[AllClassesData[class].CantripProgression]; ok {
+			cantrips += cantripsAtLevel(progression, lvl)
+		}
 	}
 
-	conMod := c.AbilityScores["CON"].Modifier
-	totalMaxHP := 0
-
-	lvl1HP := hitDie + conMod
-	if lvl1HP < 1 {
-		lvl1HP = 1
+	if cantrips > 0 {
+		c.MaxSpellSlots[0] = cantrips
 	}
-	totalMaxHP += lvl1HP
+}
+
+func cantripsAtLevel(progression map[int]int, level int) int {
+	maxLvl := 0
+	maxCount := 0
 
-	for lvl := 2; lvl <= c.Level; lvl++ {
-		levelHP := hitDieAvg + conMod
-		if levelHP < 1 {
-			levelHP = 1
+	for lvl, count := range progression {
+		if level >= lvl && lvl > maxLvl {
+			maxLvl = lvl
+			maxCount = count
 		}
-		totalMaxHP += levelHP
 	}
 
-	c.MaxHitPoints = totalMaxHP
+	return maxCount
+}
 
-	if c.CurrentHitPoints == 0 || c.CurrentHitPoints == c.MaxHitPoints {
-		c.CurrentHitPoints = totalMaxHP
-	} else if c.CurrentHitPoints > totalMaxHP {
-		c.CurrentHitPoints = totalMaxHP
+func classHitDie(class string) int {
+	if data, ok := AllClassesData[strings.ToLower(class)]; ok && data.HitDie > 0 {
+		return data.HitDie
 	}
+	return 6
 }
 
-func (c *Character) CalculateCombatStats() {
-	dexMod := c.AbilityScores["DEX"].Modifier
+// MaxHitPointsBreakdown works out maximum hit points from the class hit dice,
+// CON and the effects on them.
+func (c *Character) MaxHitPointsBreakdown() Breakdown {
+	conMod := c.AbilityScores["CON"].Modifier
+	primary := strings.ToLower(c.Class)
+	hitDice, total := 0, 0
 
-	c.Initiative = dexMod
-	c.PassivePerception = 10 + c.GetSkillModifier("Perception")
+	for class, lvl := range c.ClassLevelBreakdown() {
+		hitDie := classHitDie(class)
+		hitDieAvg := hitDie/2 + 1
 
-	baseAC := 10 + dexMod
+		for classLvl := 1; classLvl <= lvl; classLvl++ {
+			dieHP := hitDieAvg
 
-	classLower := strings.ToLower(c.Class)
+			// Only the very first character level grants the full hit die
+			if class == primary && classLvl == 1 {
+				dieHP = hitDie
+			}
 
-	if c.EquippedArmor.Name == "" {
-		if classLower == "barbarian" {
-			conMod := c.AbilityScores["CON"].Modifier
-			baseAC = 10 + dexMod + conMod
-		} else if classLower == "monk" {
-			wisMod := c.AbilityScores["WIS"].Modifier
-			baseAC = 10 + dexMod + wisMod
+			hitDice += dieHP
+			total += max(dieHP+conMod, 1)
 		}
 	}
 
-	if c.EquippedArmor.Name != "" && c.EquippedArmor.AC > 0 {
-		baseAC = c.EquippedArmor.AC
+	return c.fold("Max hit points", matching(StatMaxHitPoints, ""), false,
+		Contribution{Source: "hit dice", Value: hitDice},
+		Contribution{Source: "CON", Value: total - hitDice})
+}
 
-		if c.EquippedArmor.DexBonus != "" {
-			currentDexMod := dexMod
+func (c *Character) CalculateMaxHitPoints() {
+	c.UpdateItemAbilityScores()
 
-			if c.EquippedArmor.DexBonus == "limited" {
-				currentDexMod = int(math.Min(float64(dexMod), 2.0))
-			} else if c.EquippedArmor.DexBonus == "none" {
-				currentDexMod = 0
-			}
+	totalMaxHP := c.MaxHitPointsBreakdown().Total
 
-			baseAC += currentDexMod
-		}
-	}
+	previousMax := c.MaxHitPoints
+	c.MaxHitPoints = totalMaxHP
 
-	c.ArmorClass = baseAC
+	// A new character, or one at full health, stays at full health. A downed
+	// character stays at 0 hit points.
+	if previousMax == 0 || c.CurrentHitPoints == previousMax {
+		c.CurrentHitPoints = totalMaxHP
+	} else if c.CurrentHitPoints > totalMaxHP {
+		c.CurrentHitPoints = totalMaxHP
+	}
 
-	if c.EquippedShield.Name != "" {
-		c.ArmorClass += 2
+	if c.Dead {
+		c.CurrentHitPoints = 0
 	}
 }
 
+func (c *Character) CalculateCombatStats() {
+	c.UpdateItemAbilityScores()
+
+	c.Initiative = c.InitiativeBreakdown().Total
+	c.PassivePerception = c.PassivePerceptionBreakdown().Total
+	c.ArmorClass = c.ArmorClassBreakdown().Total
+}
+
 func (c *Character) CalculateSpellStats() {
-	classLower := strings.ToLower(c.Class)
-
-	ability := ""
-	switch classLower {
-	case "bard", "sorcerer", "warlock", "paladin":
-		ability = "CHA"
-	case "cleric", "druid", "ranger":
-		ability = "WIS"
-	case "wizard":
-		ability = "INT"
-	default:
+	data := AllClassesData[c.SpellcastingClass()]
+	c.SpellcasterType = data.SpellType
+
+	ability := data.SpellcastingAbility
+	if ability == "" {
 		c.SpellCastingAbility = ""
 		c.SpellSaveDC = 0
 		c.SpellAttackBonus = 0
@@ -313,13 +349,15 @@ func (c *Character) CalculateSpellStats() {
 	}
 	c.SpellCastingAbility = ability
 
-	mod := c.AbilityScores[ability].Modifier
-	pb := c.ProficiencyBonus
+	c.UpdateItemAbilityScores()
 
-	c.SpellSaveDC = 8 + pb + mod
-	c.SpellAttackBonus = pb + mod
+	c.SpellSaveDC = c.SpellSaveDCBreakdown().Total
+	c.SpellAttackBonus = c.SpellAttackBreakdown().Total
 }
 
+// EquipWeaponSlot puts a weapon in the main or off hand. A shield takes up
+// the off hand, and a weapon in each hand has to qualify for two-weapon
+// fighting.
 func (c *Character) EquipWeaponSlot(weapon Weapon, slot string) error {
 	switch slot {
 	case "main hand":
@@ -327,6 +365,16 @@ func (c *Character) EquipWeaponSlot(weapon Weapon, slot string) error {
 			return fmt.Errorf("main hand already occupied")
 		}
 
+		if weapon.TwoHanded && c.EquippedShield.Name != "" {
+			return fmt.Errorf("cannot wield two-handed weapon '%s' while holding shield '%s'", weapon.Name, c.EquippedShield.Name)
+		}
+
+		if !weapon.TwoHanded && c.EquippedWeaponOffHand.Name != "" {
+			if err := c.CheckTwoWeaponFighting(weapon, c.EquippedWeaponOffHand); err != nil {
+				return err
+			}
+		}
+
 		c.EquippedWeaponMainHand = weapon
 		if weapon.TwoHanded {
 			c.EquippedWeaponOffHand = Weapon{}
@@ -336,9 +384,24 @@ func (c *Character) EquipWeaponSlot(weapon Weapon, slot string) error {
 			return fmt.Errorf("off hand already occupied")
 		}
 
+		if c.EquippedShield.Name != "" {
+			return fmt.Errorf("cannot equip to off hand: it is holding shield '%s'", c.EquippedShield.Name)
+		}
+
 		if c.EquippedWeaponMainHand.TwoHanded {
 			return fmt.Errorf("cannot equip to off hand: main hand weapon '%s' is two-handed", c.EquippedWeaponMainHand.Name)
 		}
+
+		if weapon.TwoHanded {
+			return fmt.Errorf("cannot equip to off hand: '%s' is two-handed", weapon.Name)
+		}
+
+		if c.EquippedWeaponMainHand.Name != "" {
+			if err := c.CheckTwoWeaponFighting(c.EquippedWeaponMainHand, weapon); err != nil {
+				return err
+			}
+		}
+
 		c.EquippedWeaponOffHand = weapon
 	default:
 		return fmt.Errorf("invalid equipment slot '%s'. Must be 'main hand' or 'off hand'", slot)
@@ -8,22 +8,252 @@ const (
 	PreparedCasting
 )
 
+// CasterProgression describes how a class's levels count towards the
+// multiclass spellcaster table.
+type CasterProgression int
+
+const (
+	NoCaster CasterProgression = iota
+	FullCaster
+	HalfCaster
+	PactCaster
+)
+
+// RitualCasting describes which spells a class can cast as rituals.
+type RitualCasting int
+
+const (
+	NoRituals RitualCasting = iota
+	RitualsFromSpellbook
+	RitualsPrepared
+	RitualsKnown
+)
+
 type ClassData struct {
 	SpellType           SpellcasterType
 	SpellcastingAbility string
+	HitDie              int
+	CasterProgression   CasterProgression
+	CantripProgression  string
+	SubclassLevel       int
+	SavingThrows        []string
+
+	// UsesSpellbook means the class prepares spells from its spellbook rather
+	// than from the whole class list.
+	UsesSpellbook bool
+	RitualCasting RitualCasting
+
+	// WeaponProficiencies holds weapon categories ("simple", "martial") and
+	// individual weapon names, ArmorProficiencies armor categories ("light",
+	// "medium", "heavy") and "shields". Where the SRD offers a choice of
+	// tools, ToolProficiencies takes the first options.
+	WeaponProficiencies []string
+	ArmorProficiencies  []string
+	ToolProficiencies   []string
+
+	// MulticlassWeapons, MulticlassArmor and MulticlassTools are the smaller
+	// set of proficiencies the class grants when it isn't the starting class.
+	MulticlassWeapons []string
+	MulticlassArmor   []string
+	MulticlassTools   []string
+
+	// MulticlassPrereqs lists the minimum ability scores needed to multiclass
+	// into or out of the class. When MulticlassAnyOf is set, meeting any one
+	// of them is enough (e.g. Fighter needs STR 13 or DEX 13).
+	MulticlassPrereqs map[string]int
+	MulticlassAnyOf   bool
 }
 
 var AllClassesData = map[string]ClassData{
-	"fighter":   {SpellType: NoSpellcasting},
-	"rogue":     {SpellType: NoSpellcasting},
-	"barbarian": {SpellType: NoSpellcasting},
-	"monk":      {SpellType: NoSpellcasting},
+	"fighter": {
+		SpellType: NoSpellcasting, HitDie: 10, SubclassLevel: 3, SavingThrows: []string{"STR", "CON"},
+		MulticlassPrereqs: map[string]int{"STR": 13, "DEX": 13}, MulticlassAnyOf: true,
+		WeaponProficiencies: []string{"simple", "martial"},
+		ArmorProficiencies:  []string{"light", "medium", "heavy", "shields"},
+		MulticlassWeapons:   []string{"simple", "martial"},
+		MulticlassArmor:     []string{"light", "medium", "shields"},
+	},
+	"rogue": {
+		SpellType: NoSpellcasting, HitDie: 8, SubclassLevel: 3, SavingThrows: []string{"DEX", "INT"},
+		MulticlassPrereqs:   map[string]int{"DEX": 13},
+		WeaponProficiencies: []string{"simple", "crossbow, hand", "longsword", "rapier", "shortsword"},
+		ArmorProficiencies:  []string{"light"},
+		ToolProficiencies:   []string{"thieves' tools"},
+		MulticlassArmor:     []string{"light"},
+		MulticlassTools:     []string{"thieves' tools"},
+	},
+	"barbarian": {
+		SpellType: NoSpellcasting, HitDie: 12, SubclassLevel: 3, SavingThrows: []string{"STR", "CON"},
+		MulticlassPrereqs:   map[string]int{"STR": 13},
+		WeaponProficiencies: []string{"simple", "martial"},
+		ArmorProficiencies:  []string{"light", "medium", "shields"},
+		MulticlassWeapons:   []string{"simple", "martial"},
+		MulticlassArmor:     []string{"shields"},
+	},
+	"monk": {
+		SpellType: NoSpellcasting, HitDie: 8, SubclassLevel: 3, SavingThrows: []string{"STR", "DEX"},
+		MulticlassPrereqs:   map[string]int{"DEX": 13, "WIS": 13},
+		WeaponProficiencies: []string{"simple", "shortsword"},
+		ToolProficiencies:   []string{"alchemist's supplies"},
+		MulticlassWeapons:   []string{"simple", "shortsword"},
+	},
 
-	"wizard":  {SpellType: PreparedCasting, SpellcastingAbility: "INT"},
-	"cleric":  {SpellType: PreparedCasting, SpellcastingAbility: "WIS"},
-	"paladin": {SpellType: PreparedCasting, SpellcastingAbility: "CHA"},
+	"wizard": {
+		SpellType: PreparedCasting, SpellcastingAbility: "INT", HitDie: 6, SubclassLevel: 2, SavingThrows: []string{"INT", "WIS"},
+		CasterProgression: FullCaster, CantripProgression: "default_full", UsesSpellbook: true, RitualCasting: RitualsFromSpellbook,
+		MulticlassPrereqs:   map[string]int{"INT": 13},
+		WeaponProficiencies: []string{"dagger", "dart", "sling", "quarterstaff", "crossbow, light"},
+	},
+	"cleric": {
+		SpellType: PreparedCasting, SpellcastingAbility: "WIS", HitDie: 8, SubclassLevel: 1, SavingThrows: []string{"WIS", "CHA"},
+		CasterProgression: FullCaster, CantripProgression: "default_full", RitualCasting: RitualsPrepared,
+		MulticlassPrereqs:   map[string]int{"WIS": 13},
+		WeaponProficiencies: []string{"simple"},
+		ArmorProficiencies:  []string{"light", "medium", "shields"},
+		MulticlassArmor:     []string{"light", "medium", "shields"},
+	},
+	"druid": {
+		SpellType: PreparedCasting, SpellcastingAbility: "WIS", HitDie: 8, SubclassLevel: 2, SavingThrows: []string{"INT", "WIS"},
+		CasterProgression: FullCaster, CantripProgression: "druid", RitualCasting: RitualsPrepared,
+		MulticlassPrereqs:   map[string]int{"WIS": 13},
+		WeaponProficiencies: []string{"club", "dagger", "dart", "javelin", "mace", "quarterstaff", "scimitar", "sickle", "sling", "spear"},
+		ArmorProficiencies:  []string{"light", "medium", "shields"},
+		ToolProficiencies:   []string{"herbalism kit"},
+		MulticlassArmor:     []string{"light", "medium", "shields"},
+	},
+	"paladin": {
+		SpellType: PreparedCasting, SpellcastingAbility: "CHA", HitDie: 10, SubclassLevel: 3, SavingThrows: []string{"WIS", "CHA"},
+		CasterProgression:   HalfCaster,
+		MulticlassPrereqs:   map[string]int{"STR": 13, "CHA": 13},
+		WeaponProficiencies: []string{"simple", "martial"},
+		ArmorProficiencies:  []string{"light", "medium", "heavy", "shields"},
+		MulticlassWeapons:   []string{"simple", "martial"},
+		MulticlassArmor:     []string{"light", "medium", "shields"},
+	},
 
 	// Learned Casters
-	"sorcerer": {SpellType: LearnedCasting, SpellcastingAbility: "CHA"},
-	"warlock":  {SpellType: LearnedCasting, SpellcastingAbility: "CHA"},
+	"sorcerer": {
+		SpellType: LearnedCasting, SpellcastingAbility: "CHA", HitDie: 6, SubclassLevel: 1, SavingThrows: []string{"CON", "CHA"},
+		CasterProgression: FullCaster, CantripProgression: "sorcerer",
+		MulticlassPrereqs:   map[string]int{"CHA": 13},
+		WeaponProficiencies: []string{"dagger", "dart", "sling", "quarterstaff", "crossbow, light"},
+	},
+	"warlock": {
+		SpellType: LearnedCasting, SpellcastingAbility: "CHA", HitDie: 8, SubclassLevel: 1, SavingThrows: []string{"WIS", "CHA"},
+		CasterProgression: PactCaster, CantripProgression: "warlock",
+		MulticlassPrereqs:   map[string]int{"CHA": 13},
+		WeaponProficiencies: []string{"simple"},
+		ArmorProficiencies:  []string{"light"},
+		MulticlassWeapons:   []string{"simple"},
+		MulticlassArmor:     []string{"light"},
+	},
+	"bard": {
+		SpellType: LearnedCasting, SpellcastingAbility: "CHA", HitDie: 8, SubclassLevel: 3, SavingThrows: []string{"DEX", "CHA"},
+		CasterProgression: FullCaster, CantripProgression: "bard", RitualCasting: RitualsKnown,
+		MulticlassPrereqs:   map[string]int{"CHA": 13},
+		WeaponProficiencies: []string{"simple", "crossbow, hand", "longsword", "rapier", "shortsword"},
+		ArmorProficiencies:  []string{"light"},
+		ToolProficiencies:   []string{"bagpipes", "drum", "dulcimer"},
+		MulticlassArmor:     []string{"light"},
+		MulticlassTools:     []string{"bagpipes"},
+	},
+	"ranger": {
+		SpellType: LearnedCasting, SpellcastingAbility: "WIS", HitDie: 10, SubclassLevel: 3, SavingThrows: []string{"STR", "DEX"},
+		CasterProgression:   HalfCaster,
+		MulticlassPrereqs:   map[string]int{"DEX": 13, "WIS": 13},
+		WeaponProficiencies: []string{"simple", "martial"},
+		ArmorProficiencies:  []string{"light", "medium", "shields"},
+		MulticlassWeapons:   []string{"simple", "martial"},
+		MulticlassArmor:     []string{"light", "medium", "shields"},
+	},
+}
+
+type SubclassData struct {
+	Class    string
+	Features map[int][]string
+}
+
+// AllSubclassesData holds the SRD subclass for each class, keyed by lowercase
+// subclass name, with the features gained at each class level.
+var AllSubclassesData = map[string]SubclassData{
+	"path of the berserker": {
+		Class: "barbarian",
+		Features: map[int][]string{
+			3: {"Frenzy"}, 6: {"Mindless Rage"}, 10: {"Intimidating Presence"}, 14: {"Retaliation"},
+		},
+	},
+	"college of lore": {
+		Class: "bard",
+		Features: map[int][]string{
+			3: {"Bonus Proficiencies", "Cutting Words"}, 6: {"Additional Magic Secrets"}, 14: {"Peerless Skill"},
+		},
+	},
+	"life domain": {
+		Class: "cleric",
+		Features: map[int][]string{
+			1: {"Bonus Proficiency", "Disciple of Life"}, 2: {"Channel Divinity: Preserve Life"},
+			6: {"Blessed Healer"}, 8: {"Divine Strike"}, 17: {"Supreme Healing"},
+		},
+	},
+	"circle of the land": {
+		Class: "druid",
+		Features: map[int][]string{
+			2: {"Bonus Cantrip", "Natural Recovery"}, 3: {"Circle Spells"}, 6: {"Land's Stride"},
+			10: {"Nature's Ward"}, 14: {"Nature's Sanctuary"},
+		},
+	},
+	"champion": {
+		Class: "fighter",
+		Features: map[int][]string{
+			3: {"Improved Critical"}, 7: {"Remarkable Athlete"}, 10: {"Additional Fighting Style"},
+			15: {"Superior Critical"}, 18: {"Survivor"},
+		},
+	},
+	"way of the open hand": {
+		Class: "monk",
+		Features: map[int][]string{
+			3: {"Open Hand Technique"}, 6: {"Wholeness of Body"}, 11: {"Tranquility"}, 17: {"Quivering Palm"},
+		},
+	},
+	"oath of devotion": {
+		Class: "paladin",
+		Features: map[int][]string{
+			3: {"Oath Spells", "Channel Divinity: Sacred Weapon", "Channel Divinity: Turn the Unholy"},
+			7: {"Aura of Devotion"}, 15: {"Purity of Spirit"}, 20: {"Holy Nimbus"},
+		},
+	},
+	"hunter": {
+		Class: "ranger",
+		Features: map[int][]string{
+			3: {"Hunter's Prey"}, 7: {"Defensive Tactics"}, 11: {"Multiattack"}, 15: {"Superior Hunter's Defense"},
+		},
+	},
+	"thief": {
+		Class: "rogue",
+		Features: map[int][]string{
+			3: {"Fast Hands", "Second-Story Work"}, 9: {"Supreme Sneak"}, 13: {"Use Magic Device"},
+			17: {"Thief's Reflexes"},
+		},
+	},
+	"draconic bloodline": {
+		Class: "sorcerer",
+		Features: map[int][]string{
+			1: {"Dragon Ancestor", "Draconic Resilience"}, 6: {"Elemental Affinity"}, 14: {"Dragon Wings"},
+			18: {"Draconic Presence"},
+		},
+	},
+	"the fiend": {
+		Class: "warlock",
+		Features: map[int][]string{
+			1: {"Dark One's Blessing"}, 6: {"Dark One's Own Luck"}, 10: {"Fiendish Resilience"},
+			14: {"Hurl Through Hell"},
+		},
+	},
+	"school of evocation": {
+		Class: "wizard",
+		Features: map[int][]string{
+			2: {"Evocation Savant", "Sculpt Spells"}, 6: {"Potent Cantrip"}, 10: {"Empowered Evocation"},
+			14: {"Overchannel"},
+		},
+	},
 }
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type RestType string
+
+const (
+	ShortRest RestType = "short"
+	LongRest  RestType = "long"
+)
+
+// ClassFeature is one row of the class feature progression table. Uses is
+// empty for features without a limit, otherwise a "+"-separated sum of
+// numbers, ability keys (adding that modifier) and LEVEL (the class level),
+// e.g. "1+CHA". A later row with the same name replaces an earlier one, which
+// is how scaling uses (Rage, Channel Divinity) are expressed.
+type ClassFeature struct {
+	Name     string
+	Class    string
+	Level    int
+	Uses     string
+	Recharge RestType
+}
+
+type CharacterFeature struct {
+	Name          string
+	Class         string
+	Level         int
+	MaxUses       int
+	UsesRemaining int
+	Recharge      RestType
+}
+
+func (f CharacterFeature) HasLimitedUses() bool {
+	return f.MaxUses > 0
+}
+
+// featureKey identifies a feature by class as well as name, so a
+// multiclassed character keeps both classes' Channel Divinity, Extra Attack
+// or Fighting Style.
+func featureKey(class, name string) string {
+	return strings.ToLower(class) + ":" + strings.ToLower(name)
+}
+
+// UpdateClassFeatures rebuilds the character's feature list from the class
+// feature table for its current class levels. Remaining uses of features the
+// character already had are kept, adjusted by any change in maximum uses.
+func (c *Character) UpdateClassFeatures(classFeatures map[string][]ClassFeature) {
+	levels := c.ClassLevelBreakdown()
+	features := make(map[string]CharacterFeature)
+
+	for _, class := range c.ClassNames() {
+		rows := append([]ClassFeature(nil), classFeatures[class]...)
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].Level < rows[j].Level
+		})
+
+		for _, row := range rows {
+			if row.Level > levels[class] {
+				continue
+			}
+
+			features[featureKey(class, row.Name)] = CharacterFeature{
+				Name:     row.Name,
+				Class:    class,
+				Level:    row.Level,
+				MaxUses:  c.featureUses(row.Uses, levels[class]),
+				Recharge: row.Recharge,
+			}
+		}
+	}
+
+	for key, feature := range features {
+		feature.UsesRemaining = feature.MaxUses
+
+		existing, ok := c.Features[key]
+		if !ok {
+			// Characters saved before features were keyed by class
+			existing, ok = c.Features[strings.ToLower(feature.Name)]
+			ok = ok && existing.Class == feature.Class
+		}
+		if ok {
+			feature.UsesRemaining = existing.UsesRemaining + feature.MaxUses - existing.MaxUses
+			if feature.UsesRemaining < 0 {
+				feature.UsesRemaining = 0
+			}
+			if feature.UsesRemaining > feature.MaxUses {
+				feature.UsesRemaining = feature.MaxUses
+			}
+		}
+
+		features[key] = feature
+	}
+
+	c.Features = features
+}
+
+func (c *Character) featureUses(uses string, classLevel int) int {
+	if uses == "" {
+		return 0
+	}
+
+	total := 0
+	abilityBased := false
+
+	for _, term := range strings.Split(uses, "+") {
+		term = strings.TrimSpace(term)
+
+		if term == "LEVEL" {
+			total += classLevel
+			continue
+		}
+
+		if ability, ok := c.AbilityScores[term]; ok {
+			total += ability.Modifier
+			abilityBased = true
+			continue
+		}
+
+		if n, err := strconv.Atoi(term); err == nil {
+			total += n
+		}
+	}
+
+	// Features based on an ability modifier can always be used at least once
+	if abilityBased && total < 1 {
+		total = 1
+	}
+
+	return total
+}
+
+// FeatureList returns the character's class features in class order, then
+// by the level they were gained at.
+func (c *Character) FeatureList() []CharacterFeature {
+	order := make(map[string]int)
+	for i, class := range c.ClassNames() {
+		order[class] = i
+	}
+
+	var list []CharacterFeature
+	for _, feature := range c.Features {
+		list = append(list, feature)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if order[list[i].Class] != order[list[j].Class] {
+			return order[list[i].Class] < order[list[j].Class]
+		}
+		if list[i].Level != list[j].Level {
+			return list[i].Level < list[j].Level
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
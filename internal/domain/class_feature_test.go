@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestMulticlassFeatures(t *testing.T) {
+	classFeatures := map[string][]domain.ClassFeature{
+		"cleric": {
+			{Name: "Channel Divinity", Class: "cleric", Level: 2, Uses: "1", Recharge: domain.ShortRest},
+			{Name: "Channel Divinity", Class: "cleric", Level: 6, Uses: "2", Recharge: domain.ShortRest},
+		},
+		"paladin": {
+			{Name: "Fighting Style", Class: "paladin", Level: 2},
+			{Name: "Channel Divinity", Class: "paladin", Level: 3, Uses: "1", Recharge: domain.ShortRest},
+		},
+	}
+
+	char := &domain.Character{
+		Name:        "Devout",
+		Class:       "cleric",
+		ClassLevels: map[string]int{"cleric": 6, "paladin": 3},
+	}
+	char.UpdateClassFeatures(classFeatures)
+
+	uses := make(map[string]int)
+	for _, feature := range char.FeatureList() {
+		if feature.Name == "Channel Divinity" {
+			uses[feature.Class] = feature.MaxUses
+		}
+	}
+	if uses["cleric"] != 2 || uses["paladin"] != 1 {
+		t.Fatalf("channel divinity uses = %v, expected 2 from cleric and 1 from paladin", uses)
+	}
+	if got := len(char.FeatureList()); got != 3 {
+		t.Errorf("got %d features, expected 3", got)
+	}
+
+	// Spending a use of one class's feature leaves the other's alone
+	for key, feature := range char.Features {
+		if feature.Class == "cleric" && feature.Name == "Channel Divinity" {
+			feature.UsesRemaining--
+			char.Features[key] = feature
+		}
+	}
+	char.UpdateClassFeatures(classFeatures)
+
+	for _, feature := range char.FeatureList() {
+		if feature.Name != "Channel Divinity" {
+			continue
+		}
+		if want := map[string]int{"cleric": 1, "paladin": 1}[feature.Class]; feature.UsesRemaining != want {
+			t.Errorf("%s channel divinity has %d uses left, expected %d", feature.Class, feature.UsesRemaining, want)
+		}
+	}
+}
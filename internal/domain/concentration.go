@@ -0,0 +1,25 @@
+package domain
+
+// EndConcentration stops concentrating and returns the spell that ended.
+func (c *Character) EndConcentration() string {
+	ended := c.ConcentratingOn
+	c.ConcentratingOn = ""
+	return ended
+}
+
+// ConcentrationSaveDC is the DC of the CON save to keep concentrating after
+// taking damage: 10 or half the damage, whichever is higher.
+func ConcentrationSaveDC(damage int) int {
+	return max(10, damage/2)
+}
+
+// ResolveConcentrationSave ends concentration if the CON save total misses
+// the DC. It reports whether concentration was kept.
+func (c *Character) ResolveConcentrationSave(total, dc int) bool {
+	if total >= dc {
+		return true
+	}
+
+	c.EndConcentration()
+	return false
+}
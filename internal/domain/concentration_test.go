@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestConcentrationOnDamage(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   int
+		temp      int
+		damage    int
+		wantDC    int
+		wantLost  bool
+		wantSpell string
+	}{
+		{name: "HalfDamage", current: 40, damage: 22, wantDC: 11, wantSpell: "Bless"},
+		{name: "MinimumDC", current: 40, damage: 9, wantDC: 10, wantSpell: "Bless"},
+		{name: "AbsorbedByTempHP", current: 40, temp: 10, damage: 4, wantDC: 10, wantSpell: "Bless"},
+		{name: "NoDamage", current: 40, damage: 0, wantSpell: "Bless"},
+		{name: "KnockedOut", current: 5, damage: 9, wantLost: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:               tt.name,
+				MaxHitPoints:       40,
+				CurrentHitPoints:   tt.current,
+				TemporaryHitPoints: tt.temp,
+				ConcentratingOn:    "Bless",
+			}
+
+			result, err := char.TakeDamage(tt.damage, false)
+			if err != nil {
+				t.Fatalf("Failed to take damage: %v", err)
+			}
+
+			if result.ConcentrationDC != tt.wantDC {
+				t.Errorf("concentration DC = %d, expected %d", result.ConcentrationDC, tt.wantDC)
+			}
+			if lost := result.LostConcentration == "Bless"; lost != tt.wantLost {
+				t.Errorf("lost concentration = %q, expected lost: %v", result.LostConcentration, tt.wantLost)
+			}
+			if char.ConcentratingOn != tt.wantSpell {
+				t.Errorf("concentrating on %q, expected %q", char.ConcentratingOn, tt.wantSpell)
+			}
+		})
+	}
+
+	t.Run("NotConcentrating", func(t *testing.T) {
+		char := &domain.Character{Name: "Idle", MaxHitPoints: 40, CurrentHitPoints: 40}
+
+		result, err := char.TakeDamage(22, false)
+		if err != nil {
+			t.Fatalf("Failed to take damage: %v", err)
+		}
+		if result.ConcentrationDC != 0 {
+			t.Errorf("concentration DC = %d, expected none", result.ConcentrationDC)
+		}
+	})
+}
+
+func TestResolveConcentrationSave(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		dc        int
+		wantKept  bool
+		wantSpell string
+	}{
+		{name: "Passed", total: 14, dc: 11, wantKept: true, wantSpell: "Bless"},
+		{name: "MetExactly", total: 10, dc: 10, wantKept: true, wantSpell: "Bless"},
+		{name: "Failed", total: 9, dc: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, ConcentratingOn: "Bless"}
+
+			if kept := char.ResolveConcentrationSave(tt.total, tt.dc); kept != tt.wantKept {
+				t.Errorf("kept concentration = %v, expected %v", kept, tt.wantKept)
+			}
+			if char.ConcentratingOn != tt.wantSpell {
+				t.Errorf("concentrating on %q, expected %q", char.ConcentratingOn, tt.wantSpell)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	Blinded       = "blinded"
+	Charmed       = "charmed"
+	Deafened      = "deafened"
+	Exhaustion    = "exhaustion"
+	Frightened    = "frightened"
+	Grappled      = "grappled"
+	Incapacitated = "incapacitated"
+	Invisible     = "invisible"
+	Paralyzed     = "paralyzed"
+	Petrified     = "petrified"
+	Poisoned      = "poisoned"
+	Prone         = "prone"
+	Restrained    = "restrained"
+	Stunned       = "stunned"
+	Unconscious   = "unconscious"
+)
+
+// MaxExhaustion is the exhaustion level at which a character dies.
+const MaxExhaustion = 6
+
+// How long rests take in rounds of 6 seconds, for condition durations.
+const (
+	RoundsPerMinute = 10
+	ShortRestRounds = 60 * RoundsPerMinute
+	LongRestRounds  = 8 * 60 * RoundsPerMinute
+)
+
+type ConditionData struct {
+	// Effects are what the condition does to derived statistics.
+	Effects []Effect
+
+	// Incapacitated conditions stop the character taking actions, which
+	// ends concentration and prevents spellcasting.
+	Incapacitated bool
+}
+
+// AllConditions are the standard conditions. Exhaustion's effects depend on
+// its level, see exhaustionEffects.
+var AllConditions = map[string]ConditionData{
+	Blinded: {
+		Effects: []Effect{{Stat: StatAttack, Kind: EffectDisadvantage}},
+	},
+	Charmed:    {},
+	Deafened:   {},
+	Exhaustion: {},
+	Frightened: {
+		Effects: []Effect{
+			{Stat: StatCheck, Kind: EffectDisadvantage},
+			{Stat: StatAttack, Kind: EffectDisadvantage},
+		},
+	},
+	Grappled: {
+		Effects: []Effect{{Stat: StatSpeed, Kind: EffectLimit, Value: 0}},
+	},
+	Incapacitated: {Incapacitated: true},
+	Invisible: {
+		Effects: []Effect{{Stat: StatAttack, Kind: EffectAdvantage}},
+	},
+	Paralyzed: {
+		Effects:       []Effect{{Stat: StatSpeed, Kind: EffectLimit, Value: 0}},
+		Incapacitated: true,
+	},
+	Petrified: {
+		Effects:       []Effect{{Stat: StatSpeed, Kind: EffectLimit, Value: 0}},
+		Incapacitated: true,
+	},
+	Poisoned: {
+		Effects: []Effect{
+			{Stat: StatCheck, Kind: EffectDisadvantage},
+			{Stat: StatAttack, Kind: EffectDisadvantage},
+		},
+	},
+	Prone: {
+		Effects: []Effect{{Stat: StatAttack, Kind: EffectDisadvantage}},
+	},
+	Restrained: {
+		Effects: []Effect{
+			{Stat: StatSpeed, Kind: EffectLimit, Value: 0},
+			{Stat: StatAttack, Kind: EffectDisadvantage},
+			{Stat: StatSave, Target: "DEX", Kind: EffectDisadvantage},
+		},
+	},
+	Stunned: {
+		Effects:       []Effect{{Stat: StatSpeed, Kind: EffectLimit, Value: 0}},
+		Incapacitated: true,
+	},
+	Unconscious: {
+		Effects:       []Effect{{Stat: StatSpeed, Kind: EffectLimit, Value: 0}},
+		Incapacitated: true,
+	},
+}
+
+// exhaustionEffects are cumulative: disadvantage on ability checks from
+// level 1, speed halved from 2, disadvantage on attacks and saves from 3, hit
+// point maximum halved from 4 and speed 0 from 5. Level 6 is death.
+func exhaustionEffects(level int) []Effect {
+	var effects []Effect
+	if level >= 1 {
+		effects = append(effects, Effect{Stat: StatCheck, Kind: EffectDisadvantage})
+	}
+	if level >= 2 {
+		effects = append(effects, Effect{Stat: StatSpeed, Kind: EffectHalve})
+	}
+	if level >= 3 {
+		effects = append(effects,
+			Effect{Stat: StatAttack, Kind: EffectDisadvantage},
+			Effect{Stat: StatSave, Kind: EffectDisadvantage})
+	}
+	if level >= 4 {
+		effects = append(effects, Effect{Stat: StatMaxHitPoints, Kind: EffectHalve})
+	}
+	if level >= 5 {
+		effects = append(effects, Effect{Stat: StatSpeed, Kind: EffectLimit, Value: 0})
+	}
+	return effects
+}
+
+// Condition is a condition affecting the character. Rounds is how many
+// rounds it lasts, or 0 until it is removed; Level is the exhaustion level.
+type Condition struct {
+	Name   string
+	Source string
+	Rounds int
+	Level  int
+}
+
+func (cond Condition) String() string {
+	name := cond.Name
+	if cond.Name == Exhaustion {
+		name = fmt.Sprintf("exhaustion %d", cond.Level)
+	}
+
+	var details []string
+	if cond.Source != "" {
+		details = append(details, cond.Source)
+	}
+	if cond.Rounds > 0 {
+		details = append(details, fmt.Sprintf("%d rounds", cond.Rounds))
+	}
+
+	if len(details) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+// conditionEffects are the effects of the character's conditions, with the
+// condition as their source.
+func (c *Character) conditionEffects() []Effect {
+	var effects []Effect
+	for _, cond := range c.SortedConditions() {
+		source := cond.String()
+		if cond.Name == Exhaustion {
+			effects = append(effects, sourced(exhaustionEffects(cond.Level), source, SourceCondition)...)
+			continue
+		}
+		effects = append(effects, sourced(AllConditions[cond.Name].Effects, source, SourceCondition)...)
+	}
+	return effects
+}
+
+// SortedConditions lists the character's conditions by name.
+func (c *Character) SortedConditions() []Condition {
+	var conditions []Condition
+	for _, cond := range c.Conditions {
+		conditions = append(conditions, cond)
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].Name < conditions[j].Name
+	})
+	return conditions
+}
+
+func (c *Character) HasCondition(name string) bool {
+	_, ok := c.Conditions[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+func (c *Character) ExhaustionLevel() int {
+	return c.Conditions[Exhaustion].Level
+}
+
+// IsIncapacitated reports whether a condition stops the character taking
+// actions.
+func (c *Character) IsIncapacitated() bool {
+	for name := range c.Conditions {
+		if AllConditions[name].Incapacitated {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyCondition puts a condition on the character. Applying one they
+// already have replaces its source and duration, except exhaustion, whose
+// levels add up. Incapacitating conditions end concentration, and the sixth
+// level of exhaustion kills the character.
+func (c *Character) ApplyCondition(cond Condition) error {
+	cond.Name = strings.ToLower(strings.TrimSpace(cond.Name))
+
+	if _, ok := AllConditions[cond.Name]; !ok {
+		var names []string
+		for name := range AllConditions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("invalid condition '%s'. Must be one of: %s", cond.Name, strings.Join(names, ", "))
+	}
+	if cond.Rounds < 0 {
+		return fmt.Errorf("condition duration can't be negative")
+	}
+
+	if cond.Name == Exhaustion {
+		if cond.Level <= 0 {
+			cond.Level = 1
+		}
+		cond.Level = min(c.ExhaustionLevel()+cond.Level, MaxExhaustion)
+	} else {
+		cond.Level = 0
+	}
+
+	if c.Conditions == nil {
+		c.Conditions = make(map[string]Condition)
+	}
+	c.Conditions[cond.Name] = cond
+
+	if AllConditions[cond.Name].Incapacitated {
+		c.EndConcentration()
+	}
+	if c.ExhaustionLevel() >= MaxExhaustion {
+		c.die()
+	}
+
+	return nil
+}
+
+// RemoveCondition ends a condition. Exhaustion goes down one level at a time.
+func (c *Character) RemoveCondition(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	cond, ok := c.Conditions[name]
+	if !ok {
+		return fmt.Errorf("character '%s' isn't %s", c.Name, name)
+	}
+
+	if name == Exhaustion && cond.Level > 1 {
+		cond.Level--
+		c.Conditions[name] = cond
+		return nil
+	}
+
+	delete(c.Conditions, name)
+	return nil
+}
+
+// PassRounds counts down the durations of the character's conditions and
+// returns the ones that ran out.
+func (c *Character) PassRounds(rounds int) []string {
+	var ended []string
+	for name, cond := range c.Conditions {
+		if cond.Rounds == 0 {
+			continue
+		}
+
+		cond.Rounds -= rounds
+		if cond.Rounds <= 0 {
+			delete(c.Conditions, name)
+			ended = append(ended, name)
+			continue
+		}
+		c.Conditions[name] = cond
+	}
+
+	sort.Strings(ended)
+	return ended
+}
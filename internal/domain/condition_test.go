@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestConditions(t *testing.T) {
+	newChar := func() *domain.Character {
+		char := &domain.Character{
+			Name:          "Afflicted",
+			Race:          "human",
+			Class:         "fighter",
+			Level:         1,
+			ClassLevels:   map[string]int{"fighter": 1},
+			AbilityScores: map[string]domain.Ability{"STR": {Score: 10}, "DEX": {Score: 10}, "CON": {Score: 10}},
+			EquippedWeaponMainHand: domain.Weapon{
+				Equipment: domain.Equipment{Name: "club", Category: "Simple Melee"},
+				Damage:    "1d4 bludgeoning",
+			},
+		}
+		char.CalculateMaxHitPoints()
+		return char
+	}
+
+	tests := []struct {
+		name          string
+		condition     domain.Condition
+		wantSpeed     int
+		wantMaxHP     int
+		wantAttackDis bool
+		wantCheckDis  bool
+		wantDexSave   bool
+		wantDead      bool
+	}{
+		{name: "Poisoned", condition: domain.Condition{Name: "poisoned"}, wantSpeed: 30, wantMaxHP: 10, wantAttackDis: true, wantCheckDis: true},
+		{name: "Restrained", condition: domain.Condition{Name: "Restrained"}, wantSpeed: 0, wantMaxHP: 10, wantAttackDis: true, wantDexSave: true},
+		{name: "Prone", condition: domain.Condition{Name: "prone"}, wantSpeed: 30, wantMaxHP: 10, wantAttackDis: true},
+		{name: "Exhaustion1", condition: domain.Condition{Name: "exhaustion", Level: 1}, wantSpeed: 30, wantMaxHP: 10, wantCheckDis: true},
+		{name: "Exhaustion2", condition: domain.Condition{Name: "exhaustion", Level: 2}, wantSpeed: 15, wantMaxHP: 10, wantCheckDis: true},
+		{name: "Exhaustion4", condition: domain.Condition{Name: "exhaustion", Level: 4}, wantSpeed: 15, wantMaxHP: 5, wantAttackDis: true, wantCheckDis: true, wantDexSave: true},
+		{name: "Exhaustion5", condition: domain.Condition{Name: "exhaustion", Level: 5}, wantSpeed: 0, wantMaxHP: 5, wantAttackDis: true, wantCheckDis: true, wantDexSave: true},
+		{name: "Exhaustion6", condition: domain.Condition{Name: "exhaustion", Level: 6}, wantSpeed: 0, wantMaxHP: 5, wantAttackDis: true, wantCheckDis: true, wantDexSave: true, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newChar()
+			if err := char.ApplyCondition(tt.condition); err != nil {
+				t.Fatalf("Failed to apply condition: %v", err)
+			}
+			char.CalculateMaxHitPoints()
+			char.CalculateSpeed(false)
+
+			if char.Speed != tt.wantSpeed {
+				t.Errorf("speed = %d, expected %d", char.Speed, tt.wantSpeed)
+			}
+			if char.MaxHitPoints != tt.wantMaxHP {
+				t.Errorf("max HP = %d, expected %d", char.MaxHitPoints, tt.wantMaxHP)
+			}
+			if got := char.MainHandAttack().ToHit.HasDisadvantage(); got != tt.wantAttackDis {
+				t.Errorf("attack disadvantage = %v, expected %v", got, tt.wantAttackDis)
+			}
+			if got := char.SkillBreakdown("Athletics").HasDisadvantage(); got != tt.wantCheckDis {
+				t.Errorf("Athletics disadvantage = %v, expected %v", got, tt.wantCheckDis)
+			}
+			if got := char.SavingThrowBreakdown("DEX").HasDisadvantage(); got != tt.wantDexSave {
+				t.Errorf("DEX save disadvantage = %v, expected %v", got, tt.wantDexSave)
+			}
+			if char.Dead != tt.wantDead {
+				t.Errorf("dead = %v, expected %v", char.Dead, tt.wantDead)
+			}
+		})
+	}
+
+	t.Run("ExhaustionStacksAndRecovers", func(t *testing.T) {
+		char := newChar()
+		for i := 0; i < 2; i++ {
+			if err := char.ApplyCondition(domain.Condition{Name: "exhaustion", Level: 1}); err != nil {
+				t.Fatalf("Failed to apply exhaustion: %v", err)
+			}
+		}
+		if got := char.ExhaustionLevel(); got != 2 {
+			t.Fatalf("exhaustion = %d, expected 2", got)
+		}
+
+		if err := char.RemoveCondition("exhaustion"); err != nil {
+			t.Fatalf("Failed to remove exhaustion: %v", err)
+		}
+		if got := char.ExhaustionLevel(); got != 1 {
+			t.Errorf("exhaustion after removing a level = %d, expected 1", got)
+		}
+	})
+
+	t.Run("DurationRunsOut", func(t *testing.T) {
+		char := newChar()
+		if err := char.ApplyCondition(domain.Condition{Name: "frightened", Source: "dragon", Rounds: 10}); err != nil {
+			t.Fatalf("Failed to apply condition: %v", err)
+		}
+
+		if ended := char.PassRounds(9); len(ended) != 0 || !char.HasCondition("frightened") {
+			t.Errorf("condition ended after 9 of 10 rounds")
+		}
+		if ended := char.PassRounds(1); len(ended) != 1 || char.HasCondition("frightened") {
+			t.Errorf("condition still on after 10 rounds")
+		}
+	})
+}
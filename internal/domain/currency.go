@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coins lists the coin denominations from least to most valuable.
+var Coins = []string{"cp", "sp", "ep", "gp", "pp"}
+
+// changeCoins are the coins change is given in. Electrum and platinum are
+// only spent, never handed out as change.
+var changeCoins = []string{"gp", "sp", "cp"}
+
+// Purse holds the number of coins of each denomination a character carries.
+type Purse map[string]int
+
+// Total is the value of all the coins in copper pieces.
+func (p Purse) Total() int {
+	total := 0
+	for coin, count := range p {
+		total += count * CopperValue[coin]
+	}
+	return total
+}
+
+// String lists the coins from most to least valuable, e.g. "12 gp, 5 sp".
+func (p Purse) String() string {
+	var parts []string
+	for i := len(Coins) - 1; i >= 0; i-- {
+		if count := p[Coins[i]]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, Coins[i]))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 gp"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseCoins reads an amount of a single coin such as "15 gp".
+func ParseCoins(amount string) (string, int, error) {
+	copper, err := ParseCost(amount)
+	if err != nil {
+		return "", 0, err
+	}
+
+	coin := strings.ToLower(strings.Fields(amount)[1])
+	return coin, copper / CopperValue[coin], nil
+}
+
+// AddCoins puts count coins of one denomination in the character's purse.
+func (c *Character) AddCoins(coin string, count int) error {
+	coin = strings.ToLower(strings.TrimSpace(coin))
+
+	if _, ok := CopperValue[coin]; !ok {
+		return fmt.Errorf("invalid coin '%s'. Must be one of: %s", coin, strings.Join(Coins, ", "))
+	}
+	if count <= 0 {
+		return fmt.Errorf("coin count must be at least 1")
+	}
+
+	if c.Purse == nil {
+		c.Purse = make(Purse)
+	}
+	c.Purse[coin] += count
+
+	return nil
+}
+
+// CoinsFor splits a copper piece value into as few gold, silver and copper
+// coins as possible.
+func CoinsFor(copper int) Purse {
+	coins := make(Purse)
+	for _, coin := range changeCoins {
+		if count := copper / CopperValue[coin]; count > 0 {
+			coins[coin] = count
+		}
+		copper %= CopperValue[coin]
+	}
+	return coins
+}
+
+// ReceiveCopper adds a copper piece value to the purse in as few coins as
+// possible.
+func (c *Character) ReceiveCopper(copper int) {
+	if copper <= 0 {
+		return
+	}
+
+	if c.Purse == nil {
+		c.Purse = make(Purse)
+	}
+
+	for coin, count := range CoinsFor(copper) {
+		c.Purse[coin] += count
+	}
+}
+
+// Spend pays a copper piece value out of the purse. Coins are handed over from
+// the most valuable down without overpaying; if that falls short, the
+// smallest coin that covers the rest is broken and the change kept.
+func (c *Character) Spend(copper int) error {
+	if copper <= 0 {
+		return nil
+	}
+
+	if total := c.Purse.Total(); total < copper {
+		return fmt.Errorf("character '%s' is %s short, having only %s", c.Name, CoinsFor(copper-total), c.Purse)
+	}
+
+	remaining := copper
+	for i := len(Coins) - 1; i >= 0; i-- {
+		coin := Coins[i]
+		paid := min(c.Purse[coin], remaining/CopperValue[coin])
+		c.Purse[coin] -= paid
+		remaining -= paid * CopperValue[coin]
+	}
+
+	// Every coin left is now worth more than what's still owed
+	if remaining > 0 {
+		for _, coin := range Coins {
+			if c.Purse[coin] > 0 {
+				c.Purse[coin]--
+				c.ReceiveCopper(CopperValue[coin] - remaining)
+				break
+			}
+		}
+	}
+
+	for coin, count := range c.Purse {
+		if count == 0 {
+			delete(c.Purse, coin)
+		}
+	}
+
+	return nil
+}
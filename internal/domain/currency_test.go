@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestSpendMakesChange(t *testing.T) {
+	tests := []struct {
+		name  string
+		purse domain.Purse
+		spend int
+		want  domain.Purse
+	}{
+		{name: "ExactCoins", purse: domain.Purse{"gp": 2, "sp": 5}, spend: 150, want: domain.Purse{"gp": 1}},
+		{name: "BreaksGold", purse: domain.Purse{"gp": 1}, spend: 15, want: domain.Purse{"sp": 8, "cp": 5}},
+		{name: "BreaksElectrum", purse: domain.Purse{"ep": 1, "cp": 7}, spend: 15, want: domain.Purse{"sp": 4, "cp": 2}},
+		{name: "SmallCoinsFirst", purse: domain.Purse{"pp": 1, "sp": 3}, spend: 30, want: domain.Purse{"pp": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, Purse: tt.purse}
+
+			if err := char.Spend(tt.spend); err != nil {
+				t.Fatalf("Failed to spend: %v", err)
+			}
+
+			if char.Purse.String() != tt.want.String() {
+				t.Errorf("purse = %s, expected %s", char.Purse, tt.want)
+			}
+		})
+	}
+
+	char := &domain.Character{Name: "Poor", Purse: domain.Purse{"sp": 9}}
+	if err := char.Spend(100); err == nil {
+		t.Errorf("expected an error spending more than the purse holds")
+	}
+}
@@ -0,0 +1,476 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stat is a derived statistic effects can change.
+type Stat string
+
+const (
+	StatAC                Stat = "ac"
+	StatInitiative        Stat = "initiative"
+	StatPassivePerception Stat = "passive perception"
+	StatMaxHitPoints      Stat = "max hp"
+	StatSpeed             Stat = "speed"
+	StatAttack            Stat = "attack"
+	StatDamage            Stat = "damage"
+	StatSave              Stat = "save"
+	StatSkill             Stat = "skill"
+	StatCheck             Stat = "check"
+	StatAbility           Stat = "ability"
+	StatSpellAttack       Stat = "spell attack"
+	StatSpellDC           Stat = "spell save dc"
+)
+
+// EffectKind is how an effect changes a statistic.
+type EffectKind string
+
+const (
+	// EffectAdd adds Value to the statistic.
+	EffectAdd EffectKind = "add"
+	// EffectSet replaces the statistic's base with Value when that is higher.
+	EffectSet EffectKind = "set"
+	// EffectAdvantage and EffectDisadvantage apply to d20 rolls; having both
+	// cancels them out.
+	EffectAdvantage    EffectKind = "advantage"
+	EffectDisadvantage EffectKind = "disadvantage"
+	// EffectProficiency adds the proficiency bonus, unless the character is
+	// proficient already.
+	EffectProficiency EffectKind = "proficiency"
+	// EffectHalve halves the statistic, rounding down, and EffectLimit caps it
+	// at Value. Both apply after everything else.
+	EffectHalve EffectKind = "halve"
+	EffectLimit EffectKind = "limit"
+)
+
+// SourceType is where an effect comes from.
+type SourceType string
+
+const (
+	SourceRace      SourceType = "race"
+	SourceClass     SourceType = "class"
+	SourceFeat      SourceType = "feat"
+	SourceEquipment SourceType = "equipment"
+	SourceItem      SourceType = "magic item"
+	SourceCondition SourceType = "condition"
+)
+
+// Effect is a change a race, class, feat, item or condition makes to a
+// derived statistic. Target limits it to one ability, skill or weapon; an
+// empty Target applies to all of them. PerLevel values are multiplied by the
+// character level.
+type Effect struct {
+	Source     string
+	SourceType SourceType
+	Stat       Stat
+	Target     string
+	Kind       EffectKind
+	Value      int
+	PerLevel   bool
+}
+
+// effectSources are the sources that register effects on a character.
+var effectSources = []func(*Character) []Effect{
+	(*Character).raceEffects,
+	(*Character).classEffects,
+	(*Character).featEffects,
+	(*Character).equipmentEffects,
+	(*Character).itemEffects,
+	(*Character).conditionEffects,
+}
+
+// Effects gathers the effects that currently apply to the character.
+func (c *Character) Effects() []Effect {
+	var effects []Effect
+	for _, source := range effectSources {
+		effects = append(effects, source(c)...)
+	}
+	return effects
+}
+
+// sourced stamps effects from data tables with their source.
+func sourced(effects []Effect, source string, sourceType SourceType) []Effect {
+	stamped := make([]Effect, len(effects))
+	for i, e := range effects {
+		e.Source, e.SourceType = source, sourceType
+		stamped[i] = e
+	}
+	return stamped
+}
+
+func (c *Character) raceEffects() []Effect {
+	race := strings.ToLower(c.Race)
+	return sourced(AllRaces[race].Effects, race, SourceRace)
+}
+
+// classEffects are the Unarmored Defense AC formulas of any of the
+// character's classes. The monk's only works without a shield as well as
+// without armor. Only one AC formula can apply, so the best one is used.
+func (c *Character) classEffects() []Effect {
+	if c.EquippedArmor.Name != "" {
+		return nil
+	}
+
+	var best *Effect
+	for _, class := range c.ClassNames() {
+		var other string
+		switch class {
+		case "barbarian":
+			other = "CON"
+		case "monk":
+			if c.EquippedShield.Name != "" {
+				continue
+			}
+			other = "WIS"
+		default:
+			continue
+		}
+
+		ac := 10 + c.AbilityScores["DEX"].Modifier + c.AbilityScores[other].Modifier
+		if best == nil || ac > best.Value {
+			best = &Effect{
+				Source:     fmt.Sprintf("unarmored defense (10 + DEX + %s)", other),
+				SourceType: SourceClass,
+				Stat:       StatAC,
+				Kind:       EffectSet,
+				Value:      ac,
+			}
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return []Effect{*best}
+}
+
+func (c *Character) featEffects() []Effect {
+	var effects []Effect
+	for _, feat := range c.Feats {
+		data := AllFeats[feat]
+		effects = append(effects, sourced(data.Effects, feat, SourceFeat)...)
+
+		if data.DualWielder && c.IsDualWielding() {
+			effects = append(effects, Effect{
+				Source: feat, SourceType: SourceFeat, Stat: StatAC, Kind: EffectAdd, Value: DualWieldingACBonus,
+			})
+		}
+	}
+	return effects
+}
+
+// equipmentEffects are the shield's AC and the drawbacks of armor.
+func (c *Character) equipmentEffects() []Effect {
+	var effects []Effect
+
+	if sh := c.EquippedShield; sh.Name != "" {
+		effects = append(effects, Effect{
+			Source: sh.Name, SourceType: SourceEquipment, Stat: StatAC, Kind: EffectAdd, Value: sh.Bonus(),
+		})
+	}
+
+	if a := c.EquippedArmor; a.Name != "" && a.StealthDisadvantage {
+		effects = append(effects, Effect{
+			Source: a.Name, SourceType: SourceEquipment, Stat: StatSkill, Target: "Stealth", Kind: EffectDisadvantage,
+		})
+	}
+
+	if c.WearingNonProficientArmor() {
+		for _, ab := range []string{"STR", "DEX"} {
+			effects = append(effects, Effect{
+				Source: "non-proficient armor", SourceType: SourceEquipment, Stat: StatCheck, Target: ab, Kind: EffectDisadvantage,
+			})
+		}
+	}
+
+	return effects
+}
+
+// itemEffects are the modifiers of active magic items. A magic weapon's attack
+// and damage modifiers only apply to attacks with it.
+func (c *Character) itemEffects() []Effect {
+	unarmored := c.EquippedArmor.Name == "" && c.EquippedShield.Name == ""
+	var effects []Effect
+
+	for _, item := range c.ActiveMagicItems() {
+		for _, mod := range item.Modifiers {
+			if mod.Unarmored && !unarmored {
+				continue
+			}
+
+			e := Effect{
+				Source: item.Name, SourceType: SourceItem, Stat: mod.Target, Target: mod.Ability, Kind: EffectAdd, Value: mod.Value,
+			}
+			if mod.Set {
+				e.Kind = EffectSet
+			}
+			if item.Weapon != nil && (mod.Target == StatAttack || mod.Target == StatDamage) {
+				e.Target = item.Name
+			}
+			effects = append(effects, e)
+		}
+	}
+
+	return effects
+}
+
+// Contribution is one line of a breakdown.
+type Contribution struct {
+	Source string
+	Value  int
+}
+
+// Breakdown explains how a derived statistic adds up: its parts, and the
+// sources of any advantage or disadvantage on rolls with it.
+type Breakdown struct {
+	Name         string
+	Total        int
+	Parts        []Contribution
+	Proficient   bool
+	Advantage    []string
+	Disadvantage []string
+}
+
+func (b *Breakdown) add(source string, value int) {
+	b.Parts = append(b.Parts, Contribution{Source: source, Value: value})
+	b.Total += value
+}
+
+// HasAdvantage reports whether rolls have advantage, which disadvantage cancels.
+func (b Breakdown) HasAdvantage() bool {
+	return len(b.Advantage) > 0 && len(b.Disadvantage) == 0
+}
+
+// HasDisadvantage reports whether rolls have disadvantage, which advantage cancels.
+func (b Breakdown) HasDisadvantage() bool {
+	return len(b.Disadvantage) > 0 && len(b.Advantage) == 0
+}
+
+// RollExpression is the d20 roll with the total as its modifier, e.g. "1d20adv+5".
+func (b Breakdown) RollExpression() string {
+	return d20Expression(b.Total, b.HasAdvantage(), b.HasDisadvantage())
+}
+
+func d20Expression(modifier int, advantage, disadvantage bool) string {
+	die := "1d20"
+	switch {
+	case advantage:
+		die += "adv"
+	case disadvantage:
+		die += "dis"
+	}
+	return fmt.Sprintf("%s%+d", die, modifier)
+}
+
+// matching selects the effects on a statistic, either for all targets or the
+// given one.
+func matching(stat Stat, target string) func(Effect) bool {
+	return func(e Effect) bool {
+		return e.Stat == stat && (e.Target == "" || strings.EqualFold(e.Target, target))
+	}
+}
+
+// fold works a statistic out from its base parts and the effects matching it.
+// The highest Set effect replaces the base when it is higher; Add effects are
+// then summed on top, and a proficiency effect adds the proficiency bonus if
+// the base doesn't include it already. Finally the total is halved and
+// limited.
+func (c *Character) fold(name string, match func(Effect) bool, proficient bool, base ...Contribution) Breakdown {
+	b := Breakdown{Name: name}
+	for _, part := range base {
+		b.add(part.Source, part.Value)
+	}
+	if proficient {
+		b.add("proficiency", c.ProficiencyBonus)
+		b.Proficient = true
+	}
+
+	var effects []Effect
+	for _, e := range c.Effects() {
+		if match(e) {
+			effects = append(effects, e)
+		}
+	}
+
+	var set *Effect
+	for i, e := range effects {
+		if e.Kind == EffectSet && (set == nil || e.Value > set.Value) {
+			set = &effects[i]
+		}
+	}
+	if set != nil && set.Value > b.Total {
+		b.Parts, b.Total = nil, 0
+		b.add(set.Source, set.Value)
+		if b.Proficient {
+			b.add("proficiency", c.ProficiencyBonus)
+		}
+	}
+
+	for _, e := range effects {
+		switch e.Kind {
+		case EffectAdd:
+			value := e.Value
+			if e.PerLevel {
+				value *= c.Level
+			}
+			b.add(e.Source, value)
+		case EffectProficiency:
+			if !b.Proficient {
+				b.add(fmt.Sprintf("proficiency (%s)", e.Source), c.ProficiencyBonus)
+				b.Proficient = true
+			}
+		case EffectAdvantage:
+			b.Advantage = append(b.Advantage, e.Source)
+		case EffectDisadvantage:
+			b.Disadvantage = append(b.Disadvantage, e.Source)
+		}
+	}
+
+	for _, e := range effects {
+		if e.Kind == EffectHalve {
+			b.add(e.Source, b.Total/2-b.Total)
+			break
+		}
+	}
+
+	var limit *Effect
+	for i, e := range effects {
+		if e.Kind == EffectLimit && (limit == nil || e.Value < limit.Value) {
+			limit = &effects[i]
+		}
+	}
+	if limit != nil && b.Total > limit.Value {
+		b.add(limit.Source, limit.Value-b.Total)
+	}
+
+	return b
+}
+
+func abilityPart(ability string, a Ability) Contribution {
+	return Contribution{Source: ability, Value: a.Modifier}
+}
+
+// ArmorClassBreakdown works out AC from the armor worn, or 10 + DEX without
+// any, and the effects on it.
+func (c *Character) ArmorClassBreakdown() Breakdown {
+	dex := c.AbilityScores["DEX"]
+	base := []Contribution{{Source: "unarmored", Value: 10}, abilityPart("DEX", dex)}
+
+	if a := c.EquippedArmor; a.Name != "" && a.AC > 0 {
+		base = []Contribution{{Source: a.Name, Value: a.AC}}
+
+		switch a.DexBonus {
+		case "":
+		case "limited":
+			base = append(base, Contribution{Source: "DEX (max 2)", Value: min(dex.Modifier, 2)})
+		case "none":
+		default:
+			base = append(base, abilityPart("DEX", dex))
+		}
+	}
+
+	return c.fold("Armor class", matching(StatAC, ""), false, base...)
+}
+
+func (c *Character) InitiativeBreakdown() Breakdown {
+	return c.fold("Initiative", matching(StatInitiative, ""), false, abilityPart("DEX", c.AbilityScores["DEX"]))
+}
+
+func (c *Character) PassivePerceptionBreakdown() Breakdown {
+	return c.fold("Passive perception", matching(StatPassivePerception, ""), false,
+		Contribution{Source: "base", Value: 10},
+		Contribution{Source: "Perception", Value: c.GetSkillModifier("Perception")})
+}
+
+// SavingThrowBreakdown works out the saving throw modifier for an ability.
+func (c *Character) SavingThrowBreakdown(ability string) Breakdown {
+	return c.fold(ability+" save", matching(StatSave, ability), c.SavingThrowProficiencies[ability],
+		abilityPart(ability, c.AbilityScores[ability]))
+}
+
+// SkillBreakdown works out a skill modifier. Effects on checks with the
+// skill's ability apply to it too.
+func (c *Character) SkillBreakdown(skill string) Breakdown {
+	ability := AllSkills[skill]
+	skillEffect, checkEffect := matching(StatSkill, skill), matching(StatCheck, ability)
+
+	return c.fold(skill, func(e Effect) bool { return skillEffect(e) || checkEffect(e) }, c.SkillProficiencies[skill],
+		abilityPart(ability, c.AbilityScores[ability]))
+}
+
+// CheckBreakdown works out the modifier of a plain ability check.
+func (c *Character) CheckBreakdown(ability string) Breakdown {
+	return c.fold(ability+" check", matching(StatCheck, ability), false, abilityPart(ability, c.AbilityScores[ability]))
+}
+
+// SpellAttackBreakdown works out the spell attack bonus, and
+// SpellSaveDCBreakdown the spell save DC, of the spellcasting ability. Spell
+// attacks are attack rolls, so advantage and disadvantage on all attacks
+// apply to them, but bonuses to weapon attacks don't.
+func (c *Character) SpellAttackBreakdown() Breakdown {
+	ability := c.SpellCastingAbility
+	spellEffect, attackEffect := matching(StatSpellAttack, ""), matching(StatAttack, "")
+	rollMode := func(e Effect) bool {
+		return e.Target == "" && (e.Kind == EffectAdvantage || e.Kind == EffectDisadvantage) && attackEffect(e)
+	}
+
+	return c.fold("Spell attack bonus", func(e Effect) bool { return spellEffect(e) || rollMode(e) }, true,
+		abilityPart(ability, c.AbilityScores[ability]))
+}
+
+func (c *Character) SpellSaveDCBreakdown() Breakdown {
+	ability := c.SpellCastingAbility
+	return c.fold("Spell save DC", matching(StatSpellDC, ""), true,
+		Contribution{Source: "base", Value: 8}, abilityPart(ability, c.AbilityScores[ability]))
+}
+
+// Explain breaks down a statistic by name: "ac", "initiative", "passive
+// perception", "max hp", "speed", "spell attack", "spell save dc", a save such
+// as "DEX save", a skill, or a weapon attack by hand ("main hand" or "off hand").
+func (c *Character) Explain(stat string) ([]Breakdown, error) {
+	name := strings.ToLower(strings.Join(strings.Fields(stat), " "))
+
+	switch name {
+	case "ac", "armor class":
+		return []Breakdown{c.ArmorClassBreakdown()}, nil
+	case "initiative":
+		return []Breakdown{c.InitiativeBreakdown()}, nil
+	case "passive perception":
+		return []Breakdown{c.PassivePerceptionBreakdown()}, nil
+	case "max hp", "hp", "hit points":
+		return []Breakdown{c.MaxHitPointsBreakdown()}, nil
+	case "speed":
+		return []Breakdown{c.SpeedBreakdown()}, nil
+	case "spell attack", "spell save dc", "spell dc":
+		if c.SpellCastingAbility == "" {
+			return nil, fmt.Errorf("character '%s' doesn't cast spells", c.Name)
+		}
+		if name == "spell attack" {
+			return []Breakdown{c.SpellAttackBreakdown()}, nil
+		}
+		return []Breakdown{c.SpellSaveDCBreakdown()}, nil
+	case "main hand", "main", "off hand", "off":
+		attack, err := c.GetWeaponAttack(name)
+		if err != nil {
+			return nil, err
+		}
+		return []Breakdown{attack.ToHit, attack.DamageModifier}, nil
+	}
+
+	if ability, ok := strings.CutSuffix(name, " save"); ok {
+		ability = strings.ToUpper(ability)
+		if _, ok := AllAbilities[ability]; ok {
+			return []Breakdown{c.SavingThrowBreakdown(ability)}, nil
+		}
+	}
+
+	for skill := range AllSkills {
+		if strings.EqualFold(skill, name) {
+			return []Breakdown{c.SkillBreakdown(skill)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown statistic '%s'", stat)
+}
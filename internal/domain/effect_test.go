@@ -0,0 +1,126 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestEffectBreakdowns(t *testing.T) {
+	scores := func(dex, con int) map[string]domain.Ability {
+		abilities := map[string]domain.Ability{"STR": {Score: 10}, "DEX": {Score: dex}, "CON": {Score: con}, "WIS": {Score: 10}}
+		for ab, a := range abilities {
+			a.CalculateModifier()
+			abilities[ab] = a
+		}
+		return abilities
+	}
+	chainMail := domain.Armor{Equipment: domain.Equipment{Name: "chain mail"}, AC: 16, StealthDisadvantage: true}
+	shield := domain.Shield{Equipment: domain.Equipment{Name: "shield"}}
+
+	t.Run("ArmorClass", func(t *testing.T) {
+		char := &domain.Character{Name: "Breakdown", AbilityScores: scores(14, 10), EquippedArmor: chainMail, EquippedShield: shield}
+		char.ArmorProficiencies = domain.ProficiencySet{"heavy": true, domain.ShieldProficiency: true}
+
+		ac := char.ArmorClassBreakdown()
+		if ac.Total != 18 || len(ac.Parts) != 2 {
+			t.Errorf("AC = %d from %v, expected 18 from chain mail and shield", ac.Total, ac.Parts)
+		}
+		if stealth := char.SkillBreakdown("Stealth"); !stealth.HasDisadvantage() {
+			t.Errorf("expected disadvantage on Stealth in chain mail")
+		}
+	})
+
+	t.Run("UnarmoredDefense", func(t *testing.T) {
+		char := &domain.Character{Name: "Breakdown", Class: "barbarian", AbilityScores: scores(14, 16)}
+		if ac := char.ArmorClassBreakdown(); ac.Total != 15 {
+			t.Errorf("AC = %d, expected 15 from 10 + DEX + CON", ac.Total)
+		}
+
+		char.EquippedShield = shield
+		if ac := char.ArmorClassBreakdown(); ac.Total != 17 {
+			t.Errorf("AC with a shield = %d, expected 17 from 10 + DEX + CON and shield", ac.Total)
+		}
+	})
+
+	t.Run("MonkUnarmoredDefense", func(t *testing.T) {
+		abilities := scores(14, 10)
+		abilities["WIS"] = domain.Ability{Score: 16, Modifier: 3}
+		char := &domain.Character{Name: "Breakdown", Class: "monk", AbilityScores: abilities}
+		if ac := char.ArmorClassBreakdown(); ac.Total != 15 {
+			t.Errorf("AC = %d, expected 15 from 10 + DEX + WIS", ac.Total)
+		}
+
+		char.EquippedShield = shield
+		if ac := char.ArmorClassBreakdown(); ac.Total != 14 {
+			t.Errorf("AC with a shield = %d, expected 14 from 10 + DEX and shield", ac.Total)
+		}
+	})
+
+	t.Run("MulticlassUnarmoredDefense", func(t *testing.T) {
+		abilities := scores(14, 14)
+		abilities["WIS"] = domain.Ability{Score: 16, Modifier: 3}
+
+		tests := []struct {
+			name   string
+			class  string
+			levels map[string]int
+			wantAC int
+		}{
+			{name: "FighterMonk", class: "fighter", levels: map[string]int{"fighter": 1, "monk": 2}, wantAC: 15},
+			{name: "WizardBarbarian", class: "wizard", levels: map[string]int{"wizard": 1, "barbarian": 1}, wantAC: 14},
+			// Only one AC formula applies, the monk's being the better one here
+			{name: "BarbarianMonk", class: "barbarian", levels: map[string]int{"barbarian": 1, "monk": 1}, wantAC: 15},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				char := &domain.Character{Name: tt.name, Class: tt.class, ClassLevels: tt.levels, AbilityScores: abilities}
+
+				ac := char.ArmorClassBreakdown()
+				if ac.Total != tt.wantAC || len(ac.Parts) != 1 {
+					t.Errorf("AC = %d from %v, expected %d from one unarmored defense formula", ac.Total, ac.Parts, tt.wantAC)
+				}
+			})
+		}
+	})
+
+	tests := []struct {
+		name        string
+		race        string
+		feats       []string
+		wantHP      int
+		wantInit    int
+		wantPassive int
+	}{
+		{name: "NoEffects", race: "human", wantHP: 25, wantInit: 2, wantPassive: 10},
+		{name: "DwarvenToughness", race: "hill dwarf", wantHP: 28, wantInit: 2, wantPassive: 10},
+		{name: "ToughAndAlert", race: "hill dwarf", feats: []string{"tough", "alert"}, wantHP: 34, wantInit: 7, wantPassive: 10},
+		{name: "Observant", race: "human", feats: []string{"observant"}, wantHP: 25, wantInit: 2, wantPassive: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:          "Breakdown",
+				Race:          tt.race,
+				Class:         "fighter",
+				Level:         3,
+				ClassLevels:   map[string]int{"fighter": 3},
+				AbilityScores: scores(14, 12),
+				Feats:         tt.feats,
+			}
+			char.CalculateMaxHitPoints()
+			char.CalculateCombatStats()
+
+			if char.MaxHitPoints != tt.wantHP {
+				t.Errorf("max HP = %d, expected %d (%v)", char.MaxHitPoints, tt.wantHP, char.MaxHitPointsBreakdown().Parts)
+			}
+			if char.Initiative != tt.wantInit {
+				t.Errorf("initiative = %d, expected %d", char.Initiative, tt.wantInit)
+			}
+			if char.PassivePerception != tt.wantPassive {
+				t.Errorf("passive perception = %d, expected %d", char.PassivePerception, tt.wantPassive)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package domain
+
+import "strings"
+
+// Encumbrance describes how weighed down a character is by what they carry.
+type Encumbrance string
+
+const (
+	Unencumbered      Encumbrance = "unencumbered"
+	Encumbered        Encumbrance = "encumbered"
+	HeavilyEncumbered Encumbrance = "heavily encumbered"
+	OverCapacity      Encumbrance = "over capacity"
+)
+
+const (
+	// CarryingCapacityPerStrength is how many pounds each point of Strength
+	// lets a character carry.
+	CarryingCapacityPerStrength = 15
+
+	// The variant encumbrance thresholds, in pounds per point of Strength.
+	EncumberedPerStrength        = 5
+	HeavilyEncumberedPerStrength = 10
+
+	// CoinsPerPound is how many coins of any kind weigh a pound.
+	CoinsPerPound = 50
+
+	// OverCapacitySpeed is how fast a character can push or drag more than
+	// their carrying capacity, up to twice it.
+	OverCapacitySpeed = 5
+)
+
+// CarryingCapacity is how many pounds the character can carry.
+func (c *Character) CarryingCapacity() float64 {
+	return float64(c.AbilityScores["STR"].Score * CarryingCapacityPerStrength)
+}
+
+// CarriedWeight is the weight in pounds of the character's equipped items,
+// inventory, magic items and coins.
+func (c *Character) CarriedWeight() float64 {
+	weight := c.EquippedWeaponMainHand.UnitWeight() + c.EquippedWeaponOffHand.UnitWeight() +
+		c.EquippedArmor.UnitWeight() + c.EquippedShield.UnitWeight()
+
+	for _, item := range c.Inventory {
+		weight += item.Weight()
+	}
+
+	for _, item := range c.MagicItems {
+		// Equipped magic weapons and armor are already counted above
+		if item.Weapon == nil && item.Armor == nil && item.Shield == nil || !c.IsEquipped(item) {
+			weight += item.Weight
+		}
+	}
+
+	coins := 0
+	for _, count := range c.Purse {
+		coins += count
+	}
+
+	return weight + float64(coins)/CoinsPerPound
+}
+
+// EncumbranceLevel is how encumbered the character is. The variant rule adds
+// the encumbered and heavily encumbered thresholds below carrying capacity.
+func (c *Character) EncumbranceLevel(variant bool) Encumbrance {
+	weight := c.CarriedWeight()
+	str := float64(c.AbilityScores["STR"].Score)
+
+	switch {
+	case weight > c.CarryingCapacity():
+		return OverCapacity
+	case variant && weight > str*HeavilyEncumberedPerStrength:
+		return HeavilyEncumbered
+	case variant && weight > str*EncumberedPerStrength:
+		return Encumbered
+	default:
+		return Unencumbered
+	}
+}
+
+// ArmorTooHeavy reports whether the character wears heavy armor without the
+// Strength score it requires.
+func (c *Character) ArmorTooHeavy() bool {
+	required := c.EquippedArmor.StrengthRequirement
+	return required > 0 && c.AbilityScores["STR"].Score < required
+}
+
+// BaseSpeed is the race's walking speed.
+func (c *Character) BaseSpeed() int {
+	if speed := AllRaces[strings.ToLower(c.Race)].Speed; speed > 0 {
+		return speed
+	}
+	return DefaultSpeed
+}
+
+// SpeedBreakdown works out the walking speed for the character's encumbrance:
+// heavy armor worn without its Strength requirement costs 10 feet, being
+// encumbered 10 feet and heavily encumbered 20 feet. Over carrying capacity
+// the character can only push or drag their load at 5 feet, and not at all
+// past twice their capacity.
+func (c *Character) SpeedBreakdown() Breakdown {
+	base := []Contribution{{Source: c.Race, Value: c.BaseSpeed()}}
+
+	if c.ArmorTooHeavy() && !AllRaces[strings.ToLower(c.Race)].HeavyArmorSpeed {
+		base = append(base, Contribution{Source: c.EquippedArmor.Name + " without its STR requirement", Value: -10})
+	}
+
+	switch c.Encumbrance {
+	case Encumbered:
+		base = append(base, Contribution{Source: string(Encumbered), Value: -10})
+	case HeavilyEncumbered:
+		base = append(base, Contribution{Source: string(HeavilyEncumbered), Value: -20})
+	}
+
+	b := c.fold("Speed", matching(StatSpeed, ""), false, base...)
+
+	if c.Encumbrance == OverCapacity {
+		speed := min(b.Total, OverCapacitySpeed)
+		if c.CarriedWeight() > 2*c.CarryingCapacity() {
+			speed = 0
+		}
+		b.add(string(OverCapacity), speed-b.Total)
+	}
+
+	if b.Total < 0 {
+		b.add("minimum", -b.Total)
+	}
+	return b
+}
+
+// CalculateSpeed works out the character's encumbrance and walking speed.
+func (c *Character) CalculateSpeed(variantEncumbrance bool) {
+	c.Encumbrance = c.EncumbranceLevel(variantEncumbrance)
+	c.Speed = c.SpeedBreakdown().Total
+}
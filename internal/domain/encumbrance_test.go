@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestSpeedAndEncumbrance(t *testing.T) {
+	plate := domain.Armor{Equipment: domain.Equipment{Name: "plate armor", Weight: 65}, AC: 18, StrengthRequirement: 15}
+	rocks := domain.InventoryItem{Item: domain.Equipment{Name: "rock", Weight: 10}, Count: 6}
+
+	tests := []struct {
+		name            string
+		race            string
+		str             int
+		armor           domain.Armor
+		inventory       []domain.InventoryItem
+		variant         bool
+		wantSpeed       int
+		wantEncumbrance domain.Encumbrance
+	}{
+		{name: "Unarmored", race: "human", str: 10, wantSpeed: 30, wantEncumbrance: domain.Unencumbered},
+		{name: "SmallRace", race: "lightfoot halfling", str: 10, wantSpeed: 25, wantEncumbrance: domain.Unencumbered},
+		{name: "HeavyArmorTooWeak", race: "human", str: 14, armor: plate, wantSpeed: 20, wantEncumbrance: domain.Unencumbered},
+		{name: "HeavyArmorStrongEnough", race: "human", str: 15, armor: plate, wantSpeed: 30, wantEncumbrance: domain.Unencumbered},
+		{name: "DwarfIgnoresArmor", race: "hill dwarf", str: 10, armor: plate, wantSpeed: 25, wantEncumbrance: domain.Unencumbered},
+		{name: "StandardRuleNotSlowed", race: "human", str: 10, inventory: []domain.InventoryItem{rocks}, wantSpeed: 30, wantEncumbrance: domain.Unencumbered},
+		{name: "VariantEncumbered", race: "human", str: 10, inventory: []domain.InventoryItem{rocks}, variant: true, wantSpeed: 20, wantEncumbrance: domain.Encumbered},
+		{name: "VariantHeavilyEncumbered", race: "human", str: 5, inventory: []domain.InventoryItem{rocks}, variant: true, wantSpeed: 10, wantEncumbrance: domain.HeavilyEncumbered},
+		{name: "OverCapacity", race: "human", str: 3, inventory: []domain.InventoryItem{rocks}, wantSpeed: 5, wantEncumbrance: domain.OverCapacity},
+		{name: "OverTwiceCapacity", race: "human", str: 1, inventory: []domain.InventoryItem{rocks}, wantSpeed: 0, wantEncumbrance: domain.OverCapacity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:          tt.name,
+				Race:          tt.race,
+				AbilityScores: map[string]domain.Ability{"STR": {Score: tt.str}},
+				EquippedArmor: tt.armor,
+				Inventory:     tt.inventory,
+			}
+
+			char.CalculateSpeed(tt.variant)
+
+			if char.Speed != tt.wantSpeed {
+				t.Errorf("speed = %d, expected %d", char.Speed, tt.wantSpeed)
+			}
+			if char.Encumbrance != tt.wantEncumbrance {
+				t.Errorf("encumbrance = %s, expected %s", char.Encumbrance, tt.wantEncumbrance)
+			}
+		})
+	}
+}
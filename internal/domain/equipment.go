@@ -1,28 +1,124 @@
 package domain
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CopperValue is what one coin of each denomination is worth in copper pieces.
+var CopperValue = map[string]int{
+	"cp": 1,
+	"sp": 10,
+	"ep": 50,
+	"gp": 100,
+	"pp": 1000,
+}
+
 type Equipment struct {
 	Name     string
 	Type     string
 	Category string
 	Range    string
+
+	// Cost is in copper pieces and Weight in pounds, both for Quantity items
+	// (e.g. 20 arrows). A Quantity of 0 means a single item.
+	Cost     int
+	Weight   float64
+	Quantity int
+
+	// Base is the mundane item a magic weapon, armor or shield is made from.
+	Base string
+}
+
+// ParseCost converts a price such as "15 gp" to copper pieces.
+func ParseCost(cost string) (int, error) {
+	fields := strings.Fields(strings.ReplaceAll(cost, ",", ""))
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid cost '%s', expected an amount and a coin such as '15 gp'", cost)
+	}
+
+	amount, err := strconv.Atoi(fields[0])
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid cost amount '%s'", fields[0])
+	}
+
+	value, ok := CopperValue[strings.ToLower(fields[1])]
+	if !ok {
+		return 0, fmt.Errorf("invalid coin '%s' in cost '%s'", fields[1], cost)
+	}
+
+	return amount * value, nil
+}
+
+// FormatCost writes a copper piece amount in the largest of gp, sp or cp that
+// divides it evenly, the way the SRD lists prices.
+func FormatCost(copper int) string {
+	for _, coin := range []string{"gp", "sp"} {
+		if copper >= CopperValue[coin] && copper%CopperValue[coin] == 0 {
+			return fmt.Sprintf("%d %s", copper/CopperValue[coin], coin)
+		}
+	}
+	return fmt.Sprintf("%d cp", copper)
 }
 
 type Weapon struct {
 	Equipment
-	Damage    string
-	TwoHanded bool
+	Damage          string
+	VersatileDamage string
+	TwoHanded       bool
+	Properties      []string
+}
+
+func (w Weapon) HasProperty(property string) bool {
+	for _, p := range w.Properties {
+		if strings.EqualFold(p, property) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w Weapon) IsRanged() bool {
+	return strings.Contains(strings.ToLower(w.Category), "ranged") ||
+		strings.Contains(strings.ToLower(w.Range), "ranged")
+}
+
+// WeaponCategory returns "simple" or "martial", or "" when the weapon hasn't
+// been enriched with its category yet.
+func (w Weapon) WeaponCategory() string {
+	category := strings.ToLower(w.Category)
+	switch {
+	case strings.Contains(category, "martial"):
+		return "martial"
+	case strings.Contains(category, "simple"):
+		return "simple"
+	}
+	return ""
 }
 
 type Armor struct {
 	Equipment
-	AC       int
-	DexBonus string
+	AC                  int
+	DexBonus            string
+	StrengthRequirement int
+	StealthDisadvantage bool
 }
 
 type Shield struct {
 	Equipment
+	ACBonus int
+}
+
+// Bonus is the shield's AC bonus, +2 for shields saved before it was recorded.
+func (s Shield) Bonus() int {
+	if s.ACBonus == 0 {
+		return 2
+	}
+	return s.ACBonus
 }
 
 var AllWeapons map[string]Weapon
 var AllArmors map[string]Armor
 var AllShields map[string]Shield
+var AllGear map[string]Equipment
@@ -0,0 +1,60 @@
+package domain
+
+type FeatData struct {
+	// AbilityIncreases are applied when the feat is taken. AbilityChoices
+	// lists abilities of which the player picks one to increase by 1.
+	AbilityIncreases map[string]int
+	AbilityChoices   []string
+	Prerequisites    map[string]int
+
+	// SaveProficiencyInChoice grants proficiency in saves with the chosen ability
+	SaveProficiencyInChoice bool
+
+	// Effects are the feat's bonuses to derived statistics
+	Effects []Effect
+
+	// DualWielder allows two-weapon fighting with one-handed melee weapons
+	// that aren't Light, and adds to AC while wielding one in each hand.
+	DualWielder bool
+}
+
+var AllFeats = map[string]FeatData{
+	"alert": {
+		Effects: []Effect{{Stat: StatInitiative, Kind: EffectAdd, Value: 5}},
+	},
+	"athlete": {
+		AbilityChoices: []string{"STR", "DEX"},
+	},
+	"dual wielder": {
+		DualWielder: true,
+	},
+	"durable": {
+		AbilityIncreases: map[string]int{"CON": 1},
+	},
+	"grappler": {
+		Prerequisites: map[string]int{"STR": 13},
+	},
+	"keen mind": {
+		AbilityIncreases: map[string]int{"INT": 1},
+	},
+	"lucky": {},
+	"observant": {
+		AbilityChoices: []string{"INT", "WIS"},
+		Effects:        []Effect{{Stat: StatPassivePerception, Kind: EffectAdd, Value: 5}},
+	},
+	"resilient": {
+		AbilityChoices:          []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"},
+		SaveProficiencyInChoice: true,
+	},
+	"tough": {
+		Effects: []Effect{{Stat: StatMaxHitPoints, Kind: EffectAdd, Value: 2, PerLevel: true}},
+	},
+}
+
+// ASILevels are the class levels granting an Ability Score Improvement.
+// Fighters and rogues get extra ones on top of the default progression.
+var ASILevels = map[string][]int{
+	"default": {4, 8, 12, 16, 19},
+	"fighter": {4, 6, 8, 12, 14, 16, 19},
+	"rogue":   {4, 8, 10, 12, 16, 19},
+}
@@ -0,0 +1,217 @@
+package domain
+
+import "fmt"
+
+const DeathSavesNeeded = 3
+
+// DamageResult describes what happened when a character took damage.
+type DamageResult struct {
+	Damage            int
+	AbsorbedByTempHP  int
+	HitPointsLost     int
+	KnockedOut        bool
+	InstantDeath      bool
+	DeathSaveFailures int
+
+	// ConcentrationDC is the CON save needed to keep concentrating, 0 if the
+	// character wasn't concentrating or lost concentration outright.
+	ConcentrationDC   int
+	LostConcentration string
+}
+
+// DeathSaveResult describes the outcome of a single death saving throw.
+type DeathSaveResult struct {
+	Roll       int
+	Success    bool
+	Regained   bool
+	Stabilized bool
+	Died       bool
+}
+
+func (c *Character) IsDying() bool {
+	return c.CurrentHitPoints == 0 && !c.Dead && !c.Stable
+}
+
+// HealthStatus is a short description of the character's condition for display.
+func (c *Character) HealthStatus() string {
+	switch {
+	case c.Dead:
+		return "dead"
+	case c.Stable:
+		return "stable"
+	case c.IsDying():
+		return "dying"
+	}
+	return "conscious"
+}
+
+// TakeDamage applies damage to temporary hit points first, then to current
+// hit points, never going below 0. Damage at 0 hit points counts as a failed
+// death save (two on a critical hit), and damage that reduces the character
+// to 0 with at least their hit point maximum left over kills them outright.
+// A concentrating character needs a CON save, or loses concentration if
+// knocked out.
+func (c *Character) TakeDamage(amount int, critical bool) (DamageResult, error) {
+	result, err := c.applyDamage(amount, critical)
+	if err != nil || amount == 0 || c.ConcentratingOn == "" {
+		return result, err
+	}
+
+	// Falling unconscious ends concentration, otherwise a CON save is needed
+	if c.CurrentHitPoints == 0 {
+		result.LostConcentration = c.EndConcentration()
+	} else {
+		result.ConcentrationDC = ConcentrationSaveDC(amount)
+	}
+
+	return result, nil
+}
+
+func (c *Character) applyDamage(amount int, critical bool) (DamageResult, error) {
+	result := DamageResult{Damage: amount}
+
+	if amount < 0 {
+		return result, fmt.Errorf("damage can't be negative")
+	}
+	if c.Dead {
+		return result, fmt.Errorf("character '%s' is dead", c.Name)
+	}
+
+	absorbed := min(amount, c.TemporaryHitPoints)
+	c.TemporaryHitPoints -= absorbed
+	result.AbsorbedByTempHP = absorbed
+
+	remaining := amount - absorbed
+	if remaining == 0 {
+		return result, nil
+	}
+
+	if c.CurrentHitPoints == 0 {
+		c.Stable = false
+
+		if remaining >= c.MaxHitPoints {
+			c.die()
+			result.InstantDeath = true
+			return result, nil
+		}
+
+		result.DeathSaveFailures = 1
+		if critical {
+			result.DeathSaveFailures = 2
+		}
+		c.addDeathSaveFailures(result.DeathSaveFailures)
+
+		return result, nil
+	}
+
+	result.HitPointsLost = min(remaining, c.CurrentHitPoints)
+	overflow := remaining - c.CurrentHitPoints
+	c.CurrentHitPoints -= result.HitPointsLost
+
+	if c.CurrentHitPoints == 0 {
+		if overflow >= c.MaxHitPoints {
+			c.die()
+			result.InstantDeath = true
+		} else {
+			result.KnockedOut = true
+			c.resetDeathSaves()
+		}
+	}
+
+	return result, nil
+}
+
+// Heal restores hit points up to the maximum. Any healing brings a character
+// at 0 hit points back to consciousness. It returns the hit points regained.
+func (c *Character) Heal(amount int) (int, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("healing can't be negative")
+	}
+	if c.Dead {
+		return 0, fmt.Errorf("character '%s' is dead and can't be healed", c.Name)
+	}
+
+	healed := min(amount, c.MaxHitPoints-c.CurrentHitPoints)
+	c.CurrentHitPoints += healed
+
+	if c.CurrentHitPoints > 0 {
+		c.resetDeathSaves()
+	}
+
+	return healed, nil
+}
+
+// GrantTemporaryHitPoints sets temporary hit points. They don't stack, so the
+// character keeps whichever is higher; it reports whether the new amount was kept.
+func (c *Character) GrantTemporaryHitPoints(amount int) (bool, error) {
+	if amount < 0 {
+		return false, fmt.Errorf("temporary hit points can't be negative")
+	}
+	if c.Dead {
+		return false, fmt.Errorf("character '%s' is dead", c.Name)
+	}
+
+	if amount <= c.TemporaryHitPoints {
+		return false, nil
+	}
+
+	c.TemporaryHitPoints = amount
+	return true, nil
+}
+
+// RecordDeathSave records a death saving throw from the natural d20 roll.
+// 10 or higher succeeds, a 1 counts as two failures and a 20 brings the
+// character back with 1 hit point.
+func (c *Character) RecordDeathSave(roll int) (DeathSaveResult, error) {
+	result := DeathSaveResult{Roll: roll}
+
+	if roll < 1 || roll > 20 {
+		return result, fmt.Errorf("a death save is a d20 roll, got %d", roll)
+	}
+	if !c.IsDying() {
+		return result, fmt.Errorf("character '%s' is %s and doesn't make death saves", c.Name, c.HealthStatus())
+	}
+
+	switch {
+	case roll == 20:
+		c.CurrentHitPoints = 1
+		c.resetDeathSaves()
+		result.Success = true
+		result.Regained = true
+	case roll == 1:
+		c.addDeathSaveFailures(2)
+	case roll >= 10:
+		result.Success = true
+		c.DeathSaveSuccesses++
+		if c.DeathSaveSuccesses >= DeathSavesNeeded {
+			c.resetDeathSaves()
+			c.Stable = true
+			result.Stabilized = true
+		}
+	default:
+		c.addDeathSaveFailures(1)
+	}
+
+	result.Died = c.Dead
+	return result, nil
+}
+
+func (c *Character) addDeathSaveFailures(n int) {
+	c.DeathSaveFailures = min(c.DeathSaveFailures+n, DeathSavesNeeded)
+	if c.DeathSaveFailures >= DeathSavesNeeded {
+		c.die()
+	}
+}
+
+func (c *Character) die() {
+	c.CurrentHitPoints = 0
+	c.TemporaryHitPoints = 0
+	c.Stable = false
+	c.Dead = true
+}
+
+func (c *Character) resetDeathSaves() {
+	c.DeathSaveSuccesses = 0
+	c.DeathSaveFailures = 0
+	c.Stable = false
+}
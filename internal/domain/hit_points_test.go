@@ -0,0 +1,153 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestTakeDamage(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      int
+		temp         int
+		failures     int
+		damage       int
+		critical     bool
+		wantCurrent  int
+		wantTemp     int
+		wantAbsorbed int
+		wantFailures int
+		wantDown     bool
+		wantDead     bool
+	}{
+		{name: "Hit", current: 20, damage: 5, wantCurrent: 15},
+		{name: "TempAbsorbsAll", current: 20, temp: 8, damage: 5, wantCurrent: 20, wantTemp: 3, wantAbsorbed: 5},
+		{name: "TempAbsorbsSome", current: 20, temp: 4, damage: 10, wantCurrent: 14, wantAbsorbed: 4},
+		{name: "KnockedOut", current: 5, damage: 12, wantCurrent: 0, wantDown: true},
+		{name: "MassiveDamage", current: 5, damage: 25, wantCurrent: 0, wantDead: true},
+		{name: "MassiveDamageAfterTemp", current: 5, temp: 5, damage: 30, wantCurrent: 0, wantAbsorbed: 5, wantDead: true},
+		{name: "DamageWhileDown", current: 0, damage: 3, wantFailures: 1},
+		{name: "CriticalWhileDown", current: 0, damage: 3, critical: true, wantFailures: 2},
+		{name: "CriticalFinishesOff", current: 0, failures: 1, damage: 3, critical: true, wantFailures: 3, wantDead: true},
+		{name: "MassiveDamageWhileDown", current: 0, damage: 20, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:               tt.name,
+				MaxHitPoints:       20,
+				CurrentHitPoints:   tt.current,
+				TemporaryHitPoints: tt.temp,
+				DeathSaveFailures:  tt.failures,
+			}
+
+			result, err := char.TakeDamage(tt.damage, tt.critical)
+			if err != nil {
+				t.Fatalf("Failed to take damage: %v", err)
+			}
+
+			if char.CurrentHitPoints != tt.wantCurrent || char.TemporaryHitPoints != tt.wantTemp {
+				t.Errorf("HP = %d (+%d temp), expected %d (+%d temp)", char.CurrentHitPoints, char.TemporaryHitPoints, tt.wantCurrent, tt.wantTemp)
+			}
+			if result.AbsorbedByTempHP != tt.wantAbsorbed {
+				t.Errorf("absorbed = %d, expected %d", result.AbsorbedByTempHP, tt.wantAbsorbed)
+			}
+			if char.DeathSaveFailures != tt.wantFailures {
+				t.Errorf("death save failures = %d, expected %d", char.DeathSaveFailures, tt.wantFailures)
+			}
+			if result.KnockedOut != tt.wantDown {
+				t.Errorf("knocked out = %v, expected %v", result.KnockedOut, tt.wantDown)
+			}
+			if char.Dead != tt.wantDead {
+				t.Errorf("dead = %v, expected %v", char.Dead, tt.wantDead)
+			}
+		})
+	}
+
+	char := &domain.Character{Name: "Gone", MaxHitPoints: 20, Dead: true}
+	if _, err := char.TakeDamage(1, false); err == nil {
+		t.Errorf("expected an error damaging a dead character")
+	}
+}
+
+func TestRecordDeathSave(t *testing.T) {
+	tests := []struct {
+		name          string
+		successes     int
+		failures      int
+		roll          int
+		wantSuccesses int
+		wantFailures  int
+		wantHP        int
+		wantStable    bool
+		wantDead      bool
+	}{
+		{name: "Success", roll: 10, wantSuccesses: 1},
+		{name: "Failure", roll: 9, wantFailures: 1},
+		{name: "NaturalOne", roll: 1, wantFailures: 2},
+		{name: "NaturalOneKills", failures: 1, roll: 1, wantFailures: 3, wantDead: true},
+		{name: "NaturalTwenty", successes: 1, failures: 2, roll: 20, wantHP: 1},
+		{name: "ThirdSuccessStabilizes", successes: 2, failures: 1, roll: 15, wantStable: true},
+		{name: "ThirdFailureKills", successes: 2, failures: 2, roll: 5, wantSuccesses: 2, wantFailures: 3, wantDead: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:               tt.name,
+				MaxHitPoints:       20,
+				DeathSaveSuccesses: tt.successes,
+				DeathSaveFailures:  tt.failures,
+			}
+
+			result, err := char.RecordDeathSave(tt.roll)
+			if err != nil {
+				t.Fatalf("Failed to record death save: %v", err)
+			}
+
+			if char.DeathSaveSuccesses != tt.wantSuccesses || char.DeathSaveFailures != tt.wantFailures {
+				t.Errorf("death saves = %d/%d, expected %d/%d", char.DeathSaveSuccesses, char.DeathSaveFailures, tt.wantSuccesses, tt.wantFailures)
+			}
+			if char.CurrentHitPoints != tt.wantHP {
+				t.Errorf("HP = %d, expected %d", char.CurrentHitPoints, tt.wantHP)
+			}
+			if char.Stable != tt.wantStable || result.Stabilized != tt.wantStable {
+				t.Errorf("stable = %v, expected %v", char.Stable, tt.wantStable)
+			}
+			if char.Dead != tt.wantDead || result.Died != tt.wantDead {
+				t.Errorf("dead = %v, expected %v", char.Dead, tt.wantDead)
+			}
+		})
+	}
+
+	for _, roll := range []int{0, 21} {
+		char := &domain.Character{Name: "Dying", MaxHitPoints: 20}
+		if _, err := char.RecordDeathSave(roll); err == nil {
+			t.Errorf("expected an error for a death save roll of %d", roll)
+		}
+	}
+
+	char := &domain.Character{Name: "Awake", MaxHitPoints: 20, CurrentHitPoints: 5}
+	if _, err := char.RecordDeathSave(10); err == nil {
+		t.Errorf("expected an error for a conscious character making a death save")
+	}
+}
+
+func TestHealAndTemporaryHitPoints(t *testing.T) {
+	char := &domain.Character{Name: "Mended", MaxHitPoints: 20, DeathSaveFailures: 2}
+
+	if healed, err := char.Heal(30); err != nil || healed != 20 {
+		t.Fatalf("healed %d (%v), expected 20 up to the maximum", healed, err)
+	}
+	if char.DeathSaveFailures != 0 {
+		t.Errorf("death save failures = %d after healing, expected 0", char.DeathSaveFailures)
+	}
+
+	if kept, _ := char.GrantTemporaryHitPoints(5); !kept {
+		t.Errorf("expected 5 temporary HP to be kept")
+	}
+	if kept, _ := char.GrantTemporaryHitPoints(3); kept || char.TemporaryHitPoints != 5 {
+		t.Errorf("temporary HP = %d, expected the higher 5 to be kept", char.TemporaryHitPoints)
+	}
+}
@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContainerCapacity is how many pounds of gear each kind of container holds.
+var ContainerCapacity = map[string]float64{
+	"backpack": 30,
+	"basket":   40,
+	"chest":    300,
+	"pouch":    6,
+	"sack":     30,
+}
+
+// InventoryItem is a stack of identical carried items, either loose or
+// stored in a container the character carries. Equipped items aren't part
+// of the inventory.
+type InventoryItem struct {
+	Item      Equipment
+	Count     int
+	Container string
+}
+
+// UnitWeight is the weight of a single item, e.g. one arrow out of a bundle of 20.
+func (e Equipment) UnitWeight() float64 {
+	if e.Quantity > 1 {
+		return e.Weight / float64(e.Quantity)
+	}
+	return e.Weight
+}
+
+// Weight is the weight of the whole stack in pounds.
+func (i InventoryItem) Weight() float64 {
+	return i.Item.UnitWeight() * float64(i.Count)
+}
+
+// EquipmentSlots are the slots Unequip accepts.
+var EquipmentSlots = []string{"main hand", "off hand", "armor", "shield"}
+
+func normalizeItemName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func (c *Character) inventoryIndex(name, container string) int {
+	name, container = normalizeItemName(name), normalizeItemName(container)
+	for i, item := range c.Inventory {
+		if item.Item.Name == name && item.Container == container {
+			return i
+		}
+	}
+	return -1
+}
+
+// CarriedCount is how many of an item the character carries, loose or in containers.
+func (c *Character) CarriedCount(name string) int {
+	name = normalizeItemName(name)
+	count := 0
+	for _, item := range c.Inventory {
+		if item.Item.Name == name {
+			count += item.Count
+		}
+	}
+	return count
+}
+
+// ContainerLoad is the weight of everything stored in a kind of container.
+func (c *Character) ContainerLoad(container string) float64 {
+	container = normalizeItemName(container)
+	load := 0.0
+	for _, item := range c.Inventory {
+		if item.Container == container {
+			load += item.Weight()
+		}
+	}
+	return load
+}
+
+// ContainerSpace is how many pounds all carried containers of a kind hold together.
+func (c *Character) ContainerSpace(container string) float64 {
+	return ContainerCapacity[normalizeItemName(container)] * float64(c.CarriedCount(container))
+}
+
+// AddToInventory adds count items, loose when container is empty or stored
+// in a container the character carries, as long as it has room for them.
+func (c *Character) AddToInventory(item Equipment, count int, container string) (InventoryItem, error) {
+	container = normalizeItemName(container)
+
+	if count <= 0 {
+		return InventoryItem{}, fmt.Errorf("item count must be at least 1")
+	}
+
+	if container != "" {
+		if _, ok := ContainerCapacity[container]; !ok {
+			return InventoryItem{}, fmt.Errorf("'%s' is not a container", container)
+		}
+		if item.Name == container {
+			return InventoryItem{}, fmt.Errorf("a %s can't be stored in itself", container)
+		}
+		if c.CarriedCount(container) == 0 {
+			return InventoryItem{}, fmt.Errorf("character '%s' doesn't carry a %s", c.Name, container)
+		}
+
+		added := item.UnitWeight() * float64(count)
+		if load, space := c.ContainerLoad(container), c.ContainerSpace(container); load+added > space {
+			return InventoryItem{}, fmt.Errorf("not enough room: the %s holds %g lb. and already has %g lb. in it",
+				container, space, load)
+		}
+	}
+
+	if i := c.inventoryIndex(item.Name, container); i >= 0 {
+		c.Inventory[i].Count += count
+		return c.Inventory[i], nil
+	}
+
+	stack := InventoryItem{Item: item, Count: count, Container: container}
+	c.Inventory = append(c.Inventory, stack)
+
+	return stack, nil
+}
+
+// RemoveFromInventory takes count items out of the given container, or the
+// loose items when container is empty. A container can only be removed once
+// what's left can still hold its contents.
+func (c *Character) RemoveFromInventory(name string, count int, container string) (InventoryItem, error) {
+	name, container = normalizeItemName(name), normalizeItemName(container)
+
+	if count <= 0 {
+		return InventoryItem{}, fmt.Errorf("item count must be at least 1")
+	}
+
+	i := c.inventoryIndex(name, container)
+	if i < 0 {
+		if container != "" {
+			return InventoryItem{}, fmt.Errorf("character '%s' has no %s in their %s", c.Name, name, container)
+		}
+		return InventoryItem{}, fmt.Errorf("character '%s' isn't carrying a loose %s", c.Name, name)
+	}
+
+	stack := c.Inventory[i]
+	if stack.Count < count {
+		return InventoryItem{}, fmt.Errorf("character '%s' only has %d %s", c.Name, stack.Count, name)
+	}
+
+	if capacity, ok := ContainerCapacity[name]; ok {
+		space := capacity * float64(c.CarriedCount(name)-count)
+		if load := c.ContainerLoad(name); load > space {
+			return InventoryItem{}, fmt.Errorf("the %s still holds %g lb. of items, take them out first", name, load)
+		}
+	}
+
+	c.Inventory[i].Count -= count
+	if c.Inventory[i].Count == 0 {
+		c.Inventory = append(c.Inventory[:i], c.Inventory[i+1:]...)
+	}
+
+	stack.Count = count
+	return stack, nil
+}
+
+// TakeFromInventory removes one of an item, preferring loose items over
+// ones stored in containers.
+func (c *Character) TakeFromInventory(name string) (InventoryItem, error) {
+	name = normalizeItemName(name)
+
+	if c.inventoryIndex(name, "") >= 0 {
+		return c.RemoveFromInventory(name, 1, "")
+	}
+
+	for _, item := range c.Inventory {
+		if item.Item.Name == name {
+			return c.RemoveFromInventory(name, 1, item.Container)
+		}
+	}
+
+	return InventoryItem{}, fmt.Errorf("character '%s' isn't carrying a %s", c.Name, name)
+}
+
+// EquippedItem is the item in an equipment slot, empty if there's none.
+func (c *Character) EquippedItem(slot string) Equipment {
+	switch normalizeItemName(slot) {
+	case "main hand":
+		return c.EquippedWeaponMainHand.Equipment
+	case "off hand":
+		return c.EquippedWeaponOffHand.Equipment
+	case "armor":
+		return c.EquippedArmor.Equipment
+	case "shield":
+		return c.EquippedShield.Equipment
+	}
+	return Equipment{}
+}
+
+// UnequipSlot empties an equipment slot and puts the item in the inventory.
+func (c *Character) UnequipSlot(slot string) (Equipment, error) {
+	var item Equipment
+
+	switch normalizeItemName(slot) {
+	case "main hand":
+		item = c.EquippedWeaponMainHand.Equipment
+		c.EquippedWeaponMainHand = Weapon{}
+	case "off hand":
+		item = c.EquippedWeaponOffHand.Equipment
+		c.EquippedWeaponOffHand = Weapon{}
+	case "armor":
+		item = c.EquippedArmor.Equipment
+		c.EquippedArmor = Armor{}
+	case "shield":
+		item = c.EquippedShield.Equipment
+		c.EquippedShield = Shield{}
+	default:
+		return Equipment{}, fmt.Errorf("invalid equipment slot '%s'. Must be one of: %s", slot, strings.Join(EquipmentSlots, ", "))
+	}
+
+	if item.Name == "" {
+		return Equipment{}, fmt.Errorf("nothing is equipped in %s", slot)
+	}
+
+	// Magic items stay with the character's magic items
+	if _, magic := c.FindMagicItem(item.Name); !magic {
+		if _, err := c.AddToInventory(item, 1, ""); err != nil {
+			return Equipment{}, err
+		}
+	}
+
+	c.CalculateCombatStats()
+	return item, nil
+}
@@ -0,0 +1,196 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+var (
+	backpack = domain.Equipment{Name: "backpack", Weight: 5}
+	rope     = domain.Equipment{Name: "rope, hempen (50 feet)", Weight: 10}
+	arrows   = domain.Equipment{Name: "arrow", Weight: 1, Quantity: 20}
+	torch    = domain.Equipment{Name: "torch", Weight: 1}
+)
+
+func TestAddToInventory(t *testing.T) {
+	tests := []struct {
+		name      string
+		backpacks int
+		stored    int
+		item      domain.Equipment
+		count     int
+		container string
+		wantErr   bool
+	}{
+		{name: "Loose", item: torch, count: 3},
+		{name: "NoItems", item: torch, count: 0, wantErr: true},
+		{name: "InContainer", backpacks: 1, item: rope, count: 2, container: "Backpack"},
+		{name: "ContainerFull", backpacks: 1, stored: 2, item: rope, count: 2, container: "backpack", wantErr: true},
+		{name: "TwoContainersHoldMore", backpacks: 2, stored: 2, item: rope, count: 2, container: "backpack"},
+		{name: "BundlesWeighPerItem", backpacks: 1, stored: 2, item: arrows, count: 200, container: "backpack"},
+		{name: "ContainerNotCarried", item: torch, count: 1, container: "backpack", wantErr: true},
+		{name: "NotAContainer", item: torch, count: 1, container: "torch", wantErr: true},
+		{name: "ContainerInItself", backpacks: 1, item: backpack, count: 1, container: "backpack", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name}
+			if tt.backpacks > 0 {
+				if _, err := char.AddToInventory(backpack, tt.backpacks, ""); err != nil {
+					t.Fatalf("Failed to add backpacks: %v", err)
+				}
+			}
+			if tt.stored > 0 {
+				if _, err := char.AddToInventory(rope, tt.stored, "backpack"); err != nil {
+					t.Fatalf("Failed to fill backpack: %v", err)
+				}
+			}
+
+			stack, err := char.AddToInventory(tt.item, tt.count, tt.container)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddToInventory error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if stack.Container != "backpack" && tt.container != "" {
+				t.Errorf("stored in %q, expected the backpack", stack.Container)
+			}
+			want := tt.count
+			if tt.item.Name == rope.Name {
+				want += tt.stored
+			}
+			if got := char.CarriedCount(tt.item.Name); got != want {
+				t.Errorf("carrying %d %s, expected %d", got, tt.item.Name, want)
+			}
+		})
+	}
+
+	t.Run("Stacks", func(t *testing.T) {
+		char := &domain.Character{Name: "Stacker"}
+		char.AddToInventory(torch, 2, "")
+		char.AddToInventory(torch, 3, "")
+		if len(char.Inventory) != 1 || char.Inventory[0].Count != 5 {
+			t.Errorf("inventory = %+v, expected one stack of 5 torches", char.Inventory)
+		}
+	})
+}
+
+func TestRemoveFromInventory(t *testing.T) {
+	newChar := func() *domain.Character {
+		char := &domain.Character{Name: "Packer"}
+		char.AddToInventory(backpack, 2, "")
+		char.AddToInventory(torch, 3, "")
+		char.AddToInventory(rope, 4, "backpack")
+		return char
+	}
+
+	tests := []struct {
+		name      string
+		item      string
+		count     int
+		container string
+		wantErr   bool
+	}{
+		{name: "Loose", item: "Torch", count: 2},
+		{name: "FromContainer", item: "rope, hempen (50 feet)", count: 1, container: "backpack"},
+		{name: "NotLoose", item: "rope, hempen (50 feet)", count: 1, wantErr: true},
+		{name: "NotInContainer", item: "torch", count: 1, container: "backpack", wantErr: true},
+		{name: "TooMany", item: "torch", count: 4, wantErr: true},
+		{name: "NoItems", item: "torch", count: 0, wantErr: true},
+		// One backpack can't hold the 40 lb. of rope on its own
+		{name: "ContainerStillFull", item: "backpack", count: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newChar()
+			before := char.CarriedCount(tt.item)
+
+			removed, err := char.RemoveFromInventory(tt.item, tt.count, tt.container)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RemoveFromInventory error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if got := char.CarriedCount(tt.item); got != before {
+					t.Errorf("carrying %d after a failed removal, expected %d", got, before)
+				}
+				return
+			}
+
+			if removed.Count != tt.count || char.CarriedCount(tt.item) != before-tt.count {
+				t.Errorf("removed %d, carrying %d, expected to remove %d of %d", removed.Count, char.CarriedCount(tt.item), tt.count, before)
+			}
+		})
+	}
+
+	t.Run("EmptyContainer", func(t *testing.T) {
+		char := newChar()
+		if _, err := char.RemoveFromInventory("rope, hempen (50 feet)", 2, "backpack"); err != nil {
+			t.Fatalf("Failed to take rope out: %v", err)
+		}
+		if _, err := char.RemoveFromInventory("backpack", 1, ""); err != nil {
+			t.Errorf("Failed to drop a backpack once the other holds everything: %v", err)
+		}
+	})
+
+	t.Run("TakePrefersLoose", func(t *testing.T) {
+		char := newChar()
+		char.AddToInventory(torch, 1, "backpack")
+		taken, err := char.TakeFromInventory("torch")
+		if err != nil || taken.Container != "" {
+			t.Errorf("took %+v (%v), expected a loose torch", taken, err)
+		}
+	})
+}
+
+func TestUnequipSlot(t *testing.T) {
+	longsword := domain.Weapon{Equipment: domain.Equipment{Name: "longsword", Weight: 3}, Damage: "1d8 slashing"}
+	shield := domain.Shield{Equipment: domain.Equipment{Name: "shield", Weight: 6}, ACBonus: 2}
+
+	char := &domain.Character{
+		Name:                   "Unready",
+		AbilityScores:          map[string]domain.Ability{"DEX": {Score: 10}},
+		EquippedWeaponMainHand: longsword,
+		EquippedShield:         shield,
+	}
+
+	item, err := char.UnequipSlot("Main Hand")
+	if err != nil {
+		t.Fatalf("Failed to unequip: %v", err)
+	}
+	if item.Name != "longsword" || char.EquippedWeaponMainHand.Name != "" || char.CarriedCount("longsword") != 1 {
+		t.Errorf("expected the longsword moved to the inventory, got %+v", char.Inventory)
+	}
+
+	if _, err := char.UnequipSlot("shield"); err != nil {
+		t.Fatalf("Failed to unequip shield: %v", err)
+	}
+	if char.ArmorClass != 10 {
+		t.Errorf("AC = %d after removing the shield, expected 10", char.ArmorClass)
+	}
+
+	if _, err := char.UnequipSlot("main hand"); err == nil {
+		t.Errorf("expected an error unequipping an empty slot")
+	}
+	if _, err := char.UnequipSlot("belt"); err == nil {
+		t.Errorf("expected an error for an unknown slot")
+	}
+
+	t.Run("MagicItemStaysOut", func(t *testing.T) {
+		sword := domain.MagicItem{Name: "+1 longsword", Weapon: &domain.Weapon{Equipment: domain.Equipment{Name: "+1 longsword"}}}
+		char := &domain.Character{
+			Name:                   "Enchanted",
+			MagicItems:             []domain.MagicItem{sword},
+			EquippedWeaponMainHand: *sword.Weapon,
+		}
+		if _, err := char.UnequipSlot("main hand"); err != nil {
+			t.Fatalf("Failed to unequip: %v", err)
+		}
+		if len(char.Inventory) != 0 {
+			t.Errorf("expected the magic sword to stay with the magic items, got inventory %+v", char.Inventory)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpellsKnownLimit is how many spells of 1st level and higher a learned
+// caster of the given class knows at its class level.
+func (c *Character) SpellsKnownLimit(class string) int {
+	className := strings.ToLower(class)
+	return SpellsKnown[className][c.ClassLevelBreakdown()[className]]
+}
+
+func (c *Character) CantripsKnownLimit(class string) int {
+	className := strings.ToLower(class)
+	progression, ok := CantripCount[AllClassesData[className].CantripProgression]
+	if !ok {
+		return 0
+	}
+	return cantripsAtLevel(progression, c.ClassLevelBreakdown()[className])
+}
+
+// KnownSpellCount counts the known cantrips or known spells of 1st level and
+// higher learned for the given class. Spells learned before the class was
+// recorded count for every class whose list they are on.
+func (c *Character) KnownSpellCount(class string, cantrips bool) int {
+	className := strings.ToLower(class)
+
+	count := 0
+	for key, spell := range c.KnownSpells {
+		if (spell.Level == 0) != cantrips {
+			continue
+		}
+
+		learnedFor, ok := c.KnownSpellClasses[key]
+		if (ok && learnedFor == className) || (!ok && spell.IsClassSpell(className)) {
+			count++
+		}
+	}
+	return count
+}
+
+// AddKnownSpell records a spell as known, learned for the given class.
+func (c *Character) AddKnownSpell(class string, spell Spell) {
+	key := strings.ToLower(spell.Name)
+
+	if c.KnownSpells == nil {
+		c.KnownSpells = make(map[string]Spell)
+	}
+	if c.KnownSpellClasses == nil {
+		c.KnownSpellClasses = make(map[string]string)
+	}
+
+	c.KnownSpells[key] = spell
+	c.KnownSpellClasses[key] = strings.ToLower(class)
+}
+
+// CheckCanLearnSpell validates learning a spell for the given class: it must
+// be on the class list, of a level the class has slots for, and within the
+// cantrips or spells known limits. Prepared casters only learn cantrips.
+func (c *Character) CheckCanLearnSpell(class string, spell Spell) error {
+	className := strings.ToLower(class)
+	data := AllClassesData[className]
+
+	if data.SpellType == NoSpellcasting {
+		return fmt.Errorf("this class can't cast spells")
+	}
+
+	if _, ok := c.ClassLevelBreakdown()[className]; !ok {
+		return fmt.Errorf("character '%s' has no levels in %s", c.Name, className)
+	}
+
+	if data.SpellType == PreparedCasting && spell.Level > 0 {
+		return fmt.Errorf("this class prepares spells and can't learn them")
+	}
+
+	if !spell.IsClassSpell(className) {
+		return fmt.Errorf("character class '%s' is not listed as a caster for spell '%s'", className, spell.Name)
+	}
+
+	if _, ok := c.KnownSpells[strings.ToLower(spell.Name)]; ok {
+		return fmt.Errorf("character '%s' already knows the spell '%s'", c.Name, spell.Name)
+	}
+
+	if spell.Level == 0 {
+		if limit := c.CantripsKnownLimit(className); c.KnownSpellCount(className, true) >= limit {
+			return fmt.Errorf("character '%s' already knows %d cantrips, the most a level %d %s can know",
+				c.Name, limit, c.ClassLevelBreakdown()[className], className)
+		}
+		return nil
+	}
+
+	if highest := c.HighestSpellLevel(className); spell.Level > highest {
+		return fmt.Errorf("'%s' is level %d, but a level %d %s can only cast spells up to level %d",
+			spell.Name, spell.Level, c.ClassLevelBreakdown()[className], className, highest)
+	}
+
+	if limit := c.SpellsKnownLimit(className); c.KnownSpellCount(className, false) >= limit {
+		return fmt.Errorf("character '%s' already knows %d spells, the most a level %d %s can know; swap one instead",
+			c.Name, limit, c.ClassLevelBreakdown()[className], className)
+	}
+
+	return nil
+}
+
+// ForgetSpell removes a known spell, also unpreparing it.
+func (c *Character) ForgetSpell(name string) (Spell, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+
+	spell, ok := c.KnownSpells[key]
+	if !ok {
+		return Spell{}, fmt.Errorf("character '%s' doesn't know the spell '%s'", c.Name, name)
+	}
+
+	delete(c.KnownSpells, key)
+	delete(c.KnownSpellClasses, key)
+	delete(c.PreparedSpells, key)
+	delete(c.PreparedSpellClasses, key)
+
+	if c.ConcentratingOn == spell.Name {
+		c.EndConcentration()
+	}
+
+	return spell, nil
+}
+
+// SwapSpell replaces a known spell of 1st level or higher with another one.
+// Learned casters can do this once each time they gain a level in the class.
+func (c *Character) SwapSpell(class, oldName string, newSpell Spell) error {
+	className := strings.ToLower(class)
+	classLevel := c.ClassLevelBreakdown()[className]
+
+	if AllClassesData[className].SpellType != LearnedCasting {
+		return fmt.Errorf("only classes that learn spells can swap them")
+	}
+
+	if classLevel < 2 || c.LastSpellSwapLevels[className] >= classLevel {
+		return fmt.Errorf("a known spell can only be replaced once each time you gain a %s level", className)
+	}
+
+	oldSpell, ok := c.KnownSpells[strings.ToLower(strings.TrimSpace(oldName))]
+	if !ok {
+		return fmt.Errorf("character '%s' doesn't know the spell '%s'", c.Name, oldName)
+	}
+
+	if oldSpell.Level == 0 || newSpell.Level == 0 {
+		return fmt.Errorf("cantrips can't be swapped")
+	}
+
+	oldKey := strings.ToLower(oldSpell.Name)
+	if learnedFor, ok := c.KnownSpellClasses[oldKey]; ok && learnedFor != className {
+		return fmt.Errorf("'%s' was learned as a %s spell, not a %s spell", oldSpell.Name, learnedFor, className)
+	}
+
+	// The replacement is checked as if the old spell were already forgotten
+	delete(c.KnownSpells, oldKey)
+	err := c.CheckCanLearnSpell(className, newSpell)
+	c.KnownSpells[oldKey] = oldSpell
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.ForgetSpell(oldSpell.Name); err != nil {
+		return err
+	}
+
+	c.AddKnownSpell(className, newSpell)
+	if c.LastSpellSwapLevels == nil {
+		c.LastSpellSwapLevels = make(map[string]int)
+	}
+	c.LastSpellSwapLevels[className] = classLevel
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"fmt"
+	"testing"
+)
+
+func sorcererSpell(name string, level int) domain.Spell {
+	return domain.Spell{Name: name, Level: level, Class: []string{"Sorcerer", "Wizard"}}
+}
+
+func knownSpells(cantrips, spells int) map[string]domain.Spell {
+	known := make(map[string]domain.Spell)
+	for i := 1; i <= cantrips; i++ {
+		known[fmt.Sprintf("cantrip %d", i)] = sorcererSpell(fmt.Sprintf("Cantrip %d", i), 0)
+	}
+	for i := 1; i <= spells; i++ {
+		known[fmt.Sprintf("spell %d", i)] = sorcererSpell(fmt.Sprintf("Spell %d", i), 1)
+	}
+	return known
+}
+
+func TestCheckCanLearnSpell(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    string
+		level    int
+		cantrips int
+		spells   int
+		spell    domain.Spell
+		wantErr  bool
+	}{
+		{name: "WithinLimit", class: "sorcerer", level: 1, spells: 1, spell: sorcererSpell("Shield", 1)},
+		{name: "SpellsKnownFull", class: "sorcerer", level: 1, spells: 2, spell: sorcererSpell("Shield", 1), wantErr: true},
+		{name: "LimitGrowsWithLevel", class: "sorcerer", level: 2, spells: 2, spell: sorcererSpell("Shield", 1)},
+		{name: "CantripWithinLimit", class: "sorcerer", level: 1, cantrips: 3, spell: sorcererSpell("Fire Bolt", 0)},
+		{name: "CantripsKnownFull", class: "sorcerer", level: 1, cantrips: 4, spell: sorcererSpell("Fire Bolt", 0), wantErr: true},
+		{name: "TooHighLevel", class: "sorcerer", level: 1, spell: sorcererSpell("Misty Step", 2), wantErr: true},
+		{name: "AlreadyKnown", class: "sorcerer", level: 3, spells: 1, spell: sorcererSpell("Spell 1", 1), wantErr: true},
+		{name: "NotOnClassList", class: "sorcerer", level: 1, spell: domain.Spell{Name: "Cure Wounds", Level: 1, Class: []string{"Cleric"}}, wantErr: true},
+		{name: "PreparedCasterSpell", class: "cleric", level: 1, spell: domain.Spell{Name: "Bless", Level: 1, Class: []string{"Cleric"}}, wantErr: true},
+		{name: "PreparedCasterCantrip", class: "cleric", level: 1, spell: domain.Spell{Name: "Guidance", Class: []string{"Cleric"}}},
+		{name: "NotACaster", class: "fighter", level: 1, spell: sorcererSpell("Shield", 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:        tt.name,
+				Class:       tt.class,
+				Level:       tt.level,
+				ClassLevels: map[string]int{tt.class: tt.level},
+				KnownSpells: knownSpells(tt.cantrips, tt.spells),
+			}
+
+			err := char.CheckCanLearnSpell(tt.class, tt.spell)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckCanLearnSpell error = %v, expected error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSwapSpell(t *testing.T) {
+	newSorcerer := func(level int) *domain.Character {
+		return &domain.Character{
+			Name:           "Swapper",
+			Class:          "sorcerer",
+			Level:          level,
+			ClassLevels:    map[string]int{"sorcerer": level},
+			KnownSpells:    knownSpells(4, 2),
+			PreparedSpells: make(map[string]domain.Spell),
+		}
+	}
+
+	t.Run("OncePerLevel", func(t *testing.T) {
+		char := newSorcerer(2)
+
+		// The sorcerer already knows all 3 of their spells
+		char.KnownSpells["spell 3"] = sorcererSpell("Spell 3", 1)
+		if err := char.SwapSpell("sorcerer", "Spell 1", sorcererSpell("Shield", 1)); err != nil {
+			t.Fatalf("Failed to swap: %v", err)
+		}
+		_, hasOld := char.KnownSpells["spell 1"]
+		_, hasNew := char.KnownSpells["shield"]
+		if hasOld || !hasNew {
+			t.Errorf("expected Spell 1 to be replaced by Shield")
+		}
+
+		if err := char.SwapSpell("sorcerer", "Spell 2", sorcererSpell("Sleep", 1)); err == nil {
+			t.Errorf("expected an error swapping twice at the same level")
+		}
+
+		char.ClassLevels["sorcerer"] = 3
+		if err := char.SwapSpell("sorcerer", "Spell 2", sorcererSpell("Sleep", 1)); err != nil {
+			t.Errorf("Failed to swap after gaining a level: %v", err)
+		}
+		if char.LastSpellSwapLevels["sorcerer"] != 3 {
+			t.Errorf("last swap level = %d, expected 3", char.LastSpellSwapLevels["sorcerer"])
+		}
+	})
+
+	tests := []struct {
+		name     string
+		class    string
+		level    int
+		oldSpell string
+		newSpell domain.Spell
+	}{
+		{name: "NotAtFirstLevel", class: "sorcerer", level: 1, oldSpell: "Spell 1", newSpell: sorcererSpell("Shield", 1)},
+		{name: "UnknownOldSpell", class: "sorcerer", level: 3, oldSpell: "Sleep", newSpell: sorcererSpell("Shield", 1)},
+		{name: "Cantrip", class: "sorcerer", level: 3, oldSpell: "Cantrip 1", newSpell: sorcererSpell("Fire Bolt", 0)},
+		{name: "NewSpellTooHigh", class: "sorcerer", level: 3, oldSpell: "Spell 1", newSpell: sorcererSpell("Fireball", 3)},
+		{name: "PreparedCaster", class: "cleric", level: 3, oldSpell: "Spell 1", newSpell: domain.Spell{Name: "Bless", Level: 1, Class: []string{"Cleric"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newSorcerer(tt.level)
+			char.Class = tt.class
+			char.ClassLevels = map[string]int{tt.class: tt.level}
+
+			if err := char.SwapSpell(tt.class, tt.oldSpell, tt.newSpell); err == nil {
+				t.Errorf("expected an error swapping %s for %s", tt.oldSpell, tt.newSpell.Name)
+			}
+			if len(char.KnownSpells) != 6 {
+				t.Errorf("expected a rejected swap to keep the known spells, got %d", len(char.KnownSpells))
+			}
+		})
+	}
+}
+
+func TestKnownSpellLimitsPerClass(t *testing.T) {
+	warlockSpell := func(name string, level int) domain.Spell {
+		return domain.Spell{Name: name, Level: level, Class: []string{"Warlock"}}
+	}
+
+	char := &domain.Character{
+		Name:        "Pactbound",
+		Class:       "sorcerer",
+		Level:       3,
+		ClassLevels: map[string]int{"sorcerer": 1, "warlock": 2},
+	}
+
+	// A level 1 sorcerer knows 2 spells and a level 2 warlock 3
+	for _, spell := range []domain.Spell{warlockSpell("Hex", 1), warlockSpell("Armor of Agathys", 1), sorcererSpell("Shield", 1)} {
+		class := "warlock"
+		if spell.IsClassSpell("sorcerer") {
+			class = "sorcerer"
+		}
+		if err := char.CheckCanLearnSpell(class, spell); err != nil {
+			t.Fatalf("Failed to learn %s: %v", spell.Name, err)
+		}
+		char.AddKnownSpell(class, spell)
+	}
+
+	if got := char.KnownSpellCount("sorcerer", false); got != 1 {
+		t.Errorf("sorcerer spells known = %d, expected 1", got)
+	}
+	if got := char.KnownSpellCount("warlock", false); got != 2 {
+		t.Errorf("warlock spells known = %d, expected 2", got)
+	}
+
+	if err := char.CheckCanLearnSpell("sorcerer", sorcererSpell("Sleep", 1)); err != nil {
+		t.Errorf("expected the warlock spells not to count against the sorcerer limit: %v", err)
+	}
+	char.AddKnownSpell("sorcerer", sorcererSpell("Sleep", 1))
+
+	if err := char.CheckCanLearnSpell("sorcerer", sorcererSpell("Magic Missile", 1)); err == nil {
+		t.Errorf("expected an error once the sorcerer limit is reached")
+	}
+	if err := char.CheckCanLearnSpell("warlock", warlockSpell("Hellish Rebuke", 1)); err != nil {
+		t.Errorf("expected the warlock to have a spell left: %v", err)
+	}
+
+	// A forgotten spell frees up its class's slot
+	if _, err := char.ForgetSpell("Hex"); err != nil {
+		t.Fatalf("Failed to forget Hex: %v", err)
+	}
+	if got := char.KnownSpellCount("warlock", false); got != 1 {
+		t.Errorf("warlock spells known after forgetting = %d, expected 1", got)
+	}
+}
+
+func TestSwapSpellPerClass(t *testing.T) {
+	warlockSpell := func(name string) domain.Spell {
+		return domain.Spell{Name: name, Level: 1, Class: []string{"Warlock"}}
+	}
+
+	char := &domain.Character{
+		Name:        "Twice Bound",
+		Class:       "sorcerer",
+		Level:       8,
+		ClassLevels: map[string]int{"sorcerer": 5, "warlock": 3},
+	}
+	char.AddKnownSpell("sorcerer", sorcererSpell("Sleep", 1))
+	char.AddKnownSpell("sorcerer", sorcererSpell("Magic Missile", 1))
+	char.AddKnownSpell("warlock", warlockSpell("Hex"))
+	char.AddKnownSpell("warlock", warlockSpell("Armor of Agathys"))
+
+	if err := char.SwapSpell("sorcerer", "Sleep", sorcererSpell("Shield", 1)); err != nil {
+		t.Fatalf("Failed to swap a sorcerer spell: %v", err)
+	}
+
+	// The sorcerer swap at level 5 doesn't use up the warlock's
+	if err := char.SwapSpell("warlock", "Hex", warlockSpell("Hellish Rebuke")); err != nil {
+		t.Fatalf("Failed to swap a warlock spell after a sorcerer swap: %v", err)
+	}
+
+	if err := char.SwapSpell("warlock", "Armor of Agathys", warlockSpell("Witch Bolt")); err == nil {
+		t.Errorf("expected an error swapping a second warlock spell at the same level")
+	}
+	if err := char.SwapSpell("sorcerer", "Magic Missile", sorcererSpell("Burning Hands", 1)); err == nil {
+		t.Errorf("expected an error swapping a second sorcerer spell at the same level")
+	}
+
+	char.ClassLevels["warlock"] = 4
+	if err := char.SwapSpell("warlock", "Armor of Agathys", warlockSpell("Witch Bolt")); err != nil {
+		t.Errorf("Failed to swap after gaining a warlock level: %v", err)
+	}
+	if err := char.SwapSpell("sorcerer", "Magic Missile", sorcererSpell("Burning Hands", 1)); err == nil {
+		t.Errorf("expected a warlock level not to allow another sorcerer swap")
+	}
+}
@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxAttunedItems is how many magic items a character can be attuned to at once.
+const MaxAttunedItems = 3
+
+type Rarity string
+
+const (
+	Common    Rarity = "common"
+	Uncommon  Rarity = "uncommon"
+	Rare      Rarity = "rare"
+	VeryRare  Rarity = "very rare"
+	Legendary Rarity = "legendary"
+	Artifact  Rarity = "artifact"
+)
+
+// ItemModifier is a bonus a magic item grants, which becomes one of the
+// item's effects. Saves apply to every saving throw unless Ability names one;
+// ability modifiers need Ability and either increase the score by Value or,
+// with Set, raise it to Value. Unarmored modifiers only apply while the
+// character wears no armor and no shield.
+type ItemModifier struct {
+	Target    Stat
+	Ability   string
+	Value     int
+	Set       bool
+	Unarmored bool
+}
+
+// MagicItem is a magic weapon, armor or shield wrapping its base item, or a
+// standalone item such as a ring or wand. Charged items regain Recharge
+// (a dice expression) charges each dawn, up to MaxCharges.
+type MagicItem struct {
+	Name               string
+	Type               string
+	Rarity             Rarity
+	RequiresAttunement bool
+	Attuned            bool
+	Weight             float64
+	Description        string
+
+	Weapon *Weapon
+	Armor  *Armor
+	Shield *Shield
+
+	Charges    int
+	MaxCharges int
+	Recharge   string
+
+	Modifiers []ItemModifier
+}
+
+// WrapBase makes a magic weapon, armor or shield out of a base item, naming
+// it after the base, e.g. "+1 weapon" and a longsword make "+1 longsword".
+func (m MagicItem) WrapBase(weapon *Weapon, armor *Armor, shield *Shield) (MagicItem, error) {
+	var base Equipment
+
+	switch m.Type {
+	case "weapon":
+		if weapon == nil {
+			return MagicItem{}, fmt.Errorf("magic item '%s' needs a base weapon", m.Name)
+		}
+		w := *weapon
+		m.Weapon, base = &w, w.Equipment
+	case "armor":
+		if armor == nil {
+			return MagicItem{}, fmt.Errorf("magic item '%s' needs a base armor", m.Name)
+		}
+		a := *armor
+		m.Armor, base = &a, a.Equipment
+	case "shield":
+		if shield == nil {
+			return MagicItem{}, fmt.Errorf("magic item '%s' needs a base shield", m.Name)
+		}
+		sh := *shield
+		m.Shield, base = &sh, sh.Equipment
+	default:
+		return m, nil
+	}
+
+	if strings.Contains(m.Name, m.Type) {
+		m.Name = strings.Replace(m.Name, m.Type, base.Name, 1)
+	} else if m.Name != base.Name {
+		m.Name = fmt.Sprintf("%s (%s)", m.Name, base.Name)
+	}
+	m.Weight = base.Weight
+
+	switch {
+	case m.Weapon != nil:
+		m.Weapon.Name, m.Weapon.Base = m.Name, base.Name
+	case m.Armor != nil:
+		m.Armor.Name, m.Armor.Base = m.Name, base.Name
+	case m.Shield != nil:
+		m.Shield.Name, m.Shield.Base = m.Name, base.Name
+	}
+
+	return m, nil
+}
+
+// FindMagicItem returns the index of one of the character's magic items.
+func (c *Character) FindMagicItem(name string) (int, bool) {
+	name = normalizeItemName(name)
+	for i, item := range c.MagicItems {
+		if item.Name == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// IsEquipped reports whether a magic weapon, armor or shield is in use.
+// Standalone items are always worn.
+func (c *Character) IsEquipped(item MagicItem) bool {
+	switch {
+	case item.Weapon != nil:
+		return c.EquippedWeaponMainHand.Name == item.Name || c.EquippedWeaponOffHand.Name == item.Name
+	case item.Armor != nil:
+		return c.EquippedArmor.Name == item.Name
+	case item.Shield != nil:
+		return c.EquippedShield.Name == item.Name
+	}
+	return true
+}
+
+// ActiveMagicItems are the items whose modifiers apply: worn or equipped,
+// and attuned if they require it.
+func (c *Character) ActiveMagicItems() []MagicItem {
+	var active []MagicItem
+	for _, item := range c.MagicItems {
+		if item.RequiresAttunement && !item.Attuned {
+			continue
+		}
+		if c.IsEquipped(item) {
+			active = append(active, item)
+		}
+	}
+	return active
+}
+
+// BaseAbilityScore is the ability score without magic item bonuses.
+func (c *Character) BaseAbilityScore(ability string) int {
+	return c.AbilityScores[ability].Score - c.ItemAbilityBonuses[ability]
+}
+
+// UpdateItemAbilityScores applies the ability score modifiers of active magic
+// items, replacing the ones applied before. Increases stop at 20; items
+// setting a score only raise it, and several setting the same score don't
+// stack.
+func (c *Character) UpdateItemAbilityScores() {
+	bonuses := make(map[string]int)
+
+	var effects []Effect
+	for _, e := range c.itemEffects() {
+		if e.Stat == StatAbility {
+			effects = append(effects, e)
+		}
+	}
+
+	for ab := range c.AbilityScores {
+		base := c.BaseAbilityScore(ab)
+		score, increase := base, 0
+
+		for _, e := range effects {
+			if !strings.EqualFold(e.Target, ab) {
+				continue
+			}
+			if e.Kind == EffectSet {
+				score = max(score, e.Value)
+			} else {
+				increase += e.Value
+			}
+		}
+		if increase > 0 {
+			score = max(score, min(base+increase, MaxAbilityScore))
+		}
+
+		if score != base {
+			bonuses[ab] = score - base
+		}
+		c.adjustAbilityScore(ab, score-c.AbilityScores[ab].Score)
+	}
+
+	c.ItemAbilityBonuses = bonuses
+}
+
+// AttunedCount is how many magic items the character is attuned to.
+func (c *Character) AttunedCount() int {
+	count := 0
+	for _, item := range c.MagicItems {
+		if item.Attuned {
+			count++
+		}
+	}
+	return count
+}
+
+// Attune attunes the character to one of their magic items.
+func (c *Character) Attune(name string) error {
+	i, ok := c.FindMagicItem(name)
+	if !ok {
+		return fmt.Errorf("character '%s' doesn't have the magic item '%s'", c.Name, name)
+	}
+
+	item := &c.MagicItems[i]
+	if !item.RequiresAttunement {
+		return fmt.Errorf("'%s' doesn't require attunement", item.Name)
+	}
+	if item.Attuned {
+		return fmt.Errorf("character '%s' is already attuned to '%s'", c.Name, item.Name)
+	}
+	if c.AttunedCount() >= MaxAttunedItems {
+		return fmt.Errorf("character '%s' is already attuned to %d items, end an attunement first", c.Name, MaxAttunedItems)
+	}
+
+	item.Attuned = true
+	return nil
+}
+
+// EndAttunement ends the character's attunement to a magic item.
+func (c *Character) EndAttunement(name string) error {
+	i, ok := c.FindMagicItem(name)
+	if !ok || !c.MagicItems[i].Attuned {
+		return fmt.Errorf("character '%s' isn't attuned to '%s'", c.Name, name)
+	}
+
+	c.MagicItems[i].Attuned = false
+	return nil
+}
+
+// UseCharges expends charges of a magic item.
+func (c *Character) UseCharges(name string, count int) (MagicItem, error) {
+	i, ok := c.FindMagicItem(name)
+	if !ok {
+		return MagicItem{}, fmt.Errorf("character '%s' doesn't have the magic item '%s'", c.Name, name)
+	}
+
+	item := &c.MagicItems[i]
+	if item.MaxCharges == 0 {
+		return MagicItem{}, fmt.Errorf("'%s' doesn't have charges", item.Name)
+	}
+	if count <= 0 {
+		return MagicItem{}, fmt.Errorf("charge count must be at least 1")
+	}
+	if item.Charges < count {
+		return MagicItem{}, fmt.Errorf("'%s' only has %d charges left", item.Name, item.Charges)
+	}
+	if item.RequiresAttunement && !item.Attuned {
+		return MagicItem{}, fmt.Errorf("character '%s' must be attuned to '%s' to use it", c.Name, item.Name)
+	}
+
+	item.Charges -= count
+	return *item, nil
+}
+
+// RechargeMagicItem gives back charges regained at dawn, up to the maximum.
+func (c *Character) RechargeMagicItem(i, charges int) {
+	item := &c.MagicItems[i]
+	item.Charges = min(item.Charges+charges, item.MaxCharges)
+}
@@ -0,0 +1,130 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestMagicItemModifiers(t *testing.T) {
+	longsword := domain.Weapon{
+		Equipment: domain.Equipment{Name: "longsword", Category: "Martial Melee"},
+		Damage:    "1d8 slashing",
+	}
+
+	plusOne := domain.MagicItem{
+		Name:   "+1 weapon",
+		Type:   "weapon",
+		Rarity: domain.Uncommon,
+		Modifiers: []domain.ItemModifier{
+			{Target: domain.StatAttack, Value: 1},
+			{Target: domain.StatDamage, Value: 1},
+		},
+	}
+	ring := domain.MagicItem{
+		Name:               "ring of protection",
+		Type:               "ring",
+		RequiresAttunement: true,
+		Modifiers: []domain.ItemModifier{
+			{Target: domain.StatAC, Value: 1},
+			{Target: domain.StatSave, Value: 1},
+		},
+	}
+	gauntlets := domain.MagicItem{
+		Name:               "gauntlets of ogre power",
+		RequiresAttunement: true,
+		Modifiers:          []domain.ItemModifier{{Target: domain.StatAbility, Ability: "STR", Value: 19, Set: true}},
+	}
+
+	newChar := func() *domain.Character {
+		return &domain.Character{
+			Name: "Magic",
+			AbilityScores: map[string]domain.Ability{
+				"STR": {Score: 14, Modifier: 2}, "DEX": {Score: 10}, "CON": {Score: 10},
+			},
+			WeaponProficiencies: domain.ProficiencySet{"longsword": true},
+			ProficiencyBonus:    2,
+		}
+	}
+
+	t.Run("WrapsBaseWeapon", func(t *testing.T) {
+		char := newChar()
+		sword, err := plusOne.WrapBase(&longsword, nil, nil)
+		if err != nil {
+			t.Fatalf("Failed to wrap: %v", err)
+		}
+		if sword.Name != "+1 longsword" {
+			t.Errorf("name = %q, expected %q", sword.Name, "+1 longsword")
+		}
+
+		char.MagicItems = append(char.MagicItems, sword)
+		if err := char.EquipWeaponSlot(*sword.Weapon, "main hand"); err != nil {
+			t.Fatalf("Failed to equip: %v", err)
+		}
+
+		// STR +2 and proficiency with the base longsword, plus the item's +1
+		attack := char.MainHandAttack()
+		if attack.AttackBonus != 5 || attack.DamageBonus != 3 {
+			t.Errorf("attack %+d, damage %+d, expected +5, +3", attack.AttackBonus, attack.DamageBonus)
+		}
+	})
+
+	t.Run("AttunementRequired", func(t *testing.T) {
+		char := newChar()
+		char.MagicItems = append(char.MagicItems, ring)
+		char.CalculateCombatStats()
+		if char.ArmorClass != 10 || char.GetSavingThrowModifier("DEX") != 0 {
+			t.Errorf("unattuned ring: AC %d, DEX save %+d, expected 10, +0", char.ArmorClass, char.GetSavingThrowModifier("DEX"))
+		}
+
+		if err := char.Attune("ring of protection"); err != nil {
+			t.Fatalf("Failed to attune: %v", err)
+		}
+		char.CalculateCombatStats()
+		if char.ArmorClass != 11 || char.GetSavingThrowModifier("DEX") != 1 {
+			t.Errorf("attuned ring: AC %d, DEX save %+d, expected 11, +1", char.ArmorClass, char.GetSavingThrowModifier("DEX"))
+		}
+	})
+
+	t.Run("SetsAbilityScore", func(t *testing.T) {
+		char := newChar()
+		char.MagicItems = append(char.MagicItems, gauntlets)
+		if err := char.Attune("gauntlets of ogre power"); err != nil {
+			t.Fatalf("Failed to attune: %v", err)
+		}
+
+		char.CalculateCombatStats()
+		char.CalculateCombatStats()
+		if got := char.AbilityScores["STR"].Score; got != 19 {
+			t.Errorf("STR = %d, expected 19", got)
+		}
+		if got := char.BaseAbilityScore("STR"); got != 14 {
+			t.Errorf("base STR = %d, expected 14", got)
+		}
+
+		if err := char.EndAttunement("gauntlets of ogre power"); err != nil {
+			t.Fatalf("Failed to end attunement: %v", err)
+		}
+		char.CalculateCombatStats()
+		if got := char.AbilityScores["STR"].Score; got != 14 {
+			t.Errorf("STR after ending attunement = %d, expected 14", got)
+		}
+	})
+
+	t.Run("AttunementLimit", func(t *testing.T) {
+		char := newChar()
+		for _, name := range []string{"a", "b", "c", "d"} {
+			item := ring
+			item.Name = name
+			char.MagicItems = append(char.MagicItems, item)
+		}
+
+		for _, name := range []string{"a", "b", "c"} {
+			if err := char.Attune(name); err != nil {
+				t.Fatalf("Failed to attune to %s: %v", name, err)
+			}
+		}
+		if err := char.Attune("d"); err == nil {
+			t.Errorf("expected an error attuning to a fourth item")
+		}
+	})
+}
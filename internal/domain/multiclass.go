@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const MaxCharacterLevel = 20
+
+// ClassLevelBreakdown returns the character's level in each of its classes,
+// keyed by lowercase class name. Characters saved before multiclassing was
+// supported only have Class and Level, so those are used as a fallback.
+func (c *Character) ClassLevelBreakdown() map[string]int {
+	levels := make(map[string]int)
+
+	for class, lvl := range c.ClassLevels {
+		if lvl > 0 {
+			levels[strings.ToLower(class)] = lvl
+		}
+	}
+
+	if len(levels) == 0 && c.Class != "" {
+		levels[strings.ToLower(c.Class)] = c.Level
+	}
+
+	return levels
+}
+
+// ClassNames returns the character's classes with the starting class first
+// and the rest in alphabetical order.
+func (c *Character) ClassNames() []string {
+	primary := strings.ToLower(c.Class)
+	levels := c.ClassLevelBreakdown()
+
+	var names []string
+	for class := range levels {
+		if class != primary {
+			names = append(names, class)
+		}
+	}
+	sort.Strings(names)
+
+	if _, ok := levels[primary]; ok {
+		names = append([]string{primary}, names...)
+	}
+
+	return names
+}
+
+func (c *Character) IsMulticlassed() bool {
+	return len(c.ClassLevelBreakdown()) > 1
+}
+
+// ClassSummary formats the per-class level breakdown, e.g. "fighter 2 / wizard 3".
+func (c *Character) ClassSummary() string {
+	levels := c.ClassLevelBreakdown()
+
+	var parts []string
+	for _, class := range c.ClassNames() {
+		parts = append(parts, fmt.Sprintf("%s %d", class, levels[class]))
+	}
+
+	return strings.Join(parts, " / ")
+}
+
+// CheckMulticlassPrereqs verifies the character's ability scores meet the
+// multiclassing prerequisites of the given class.
+func (c *Character) CheckMulticlassPrereqs(class string) error {
+	className := strings.ToLower(class)
+	data, ok := AllClassesData[className]
+	if !ok {
+		return fmt.Errorf("unknown class '%s'", class)
+	}
+
+	var missing []string
+	for _, ab := range []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"} {
+		minScore, ok := data.MulticlassPrereqs[ab]
+		if !ok {
+			continue
+		}
+
+		if c.AbilityScores[ab].Score >= minScore {
+			if data.MulticlassAnyOf {
+				return nil
+			}
+			continue
+		}
+
+		missing = append(missing, fmt.Sprintf("%s %d", ab, minScore))
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	separator := " and "
+	if data.MulticlassAnyOf {
+		separator = " or "
+	}
+
+	return fmt.Errorf("multiclassing with %s requires %s", className, strings.Join(missing, separator))
+}
+
+// AddClassLevel gains one level in the given class. Taking a first level in
+// a new class requires meeting the prerequisites of every class the
+// character already has as well as the new one.
+func (c *Character) AddClassLevel(class string) error {
+	className := strings.ToLower(class)
+	if _, ok := AllClassesData[className]; !ok {
+		return fmt.Errorf("unknown class '%s'", class)
+	}
+
+	if c.Level >= MaxCharacterLevel {
+		return fmt.Errorf("character '%s' is already at the maximum level of %d", c.Name, MaxCharacterLevel)
+	}
+
+	levels := c.ClassLevelBreakdown()
+
+	if _, ok := levels[className]; !ok {
+		for existing := range levels {
+			if err := c.CheckMulticlassPrereqs(existing); err != nil {
+				return err
+			}
+		}
+
+		if err := c.CheckMulticlassPrereqs(className); err != nil {
+			return err
+		}
+	}
+
+	levels[className]++
+	c.ClassLevels = levels
+	c.UpdateProficiencyBonus(c.Level + 1)
+
+	return nil
+}
+
+// SetTotalLevel changes the character's total level by adjusting the level
+// of its starting class, keeping levels in other classes untouched. The
+// starting class's subclass is dropped if it falls below its subclass level.
+func (c *Character) SetTotalLevel(level int) error {
+	if level < 1 || level > MaxCharacterLevel {
+		return fmt.Errorf("level must be between 1 and %d", MaxCharacterLevel)
+	}
+
+	levels := c.ClassLevelBreakdown()
+	primary := strings.ToLower(c.Class)
+
+	total := 0
+	for _, lvl := range levels {
+		total += lvl
+	}
+
+	newPrimaryLevel := levels[primary] + level - total
+	if newPrimaryLevel < 1 {
+		return fmt.Errorf("cannot set level %d: levels in other classes already total %d", level, total-levels[primary])
+	}
+
+	c.RemoveImprovementsAbove(primary, newPrimaryLevel)
+
+	if newPrimaryLevel < AllClassesData[primary].SubclassLevel {
+		delete(c.Subclasses, primary)
+	}
+
+	levels[primary] = newPrimaryLevel
+	c.ClassLevels = levels
+	c.UpdateProficiencyBonus(level)
+
+	return nil
+}
+
+// SpellcastingClass returns the class whose spellcasting ability is used for
+// the character's spell save DC and attack bonus: the starting class if it
+// casts spells, otherwise the highest-level spellcasting class.
+func (c *Character) SpellcastingClass() string {
+	primary := strings.ToLower(c.Class)
+	if data, ok := AllClassesData[primary]; ok && data.SpellcastingAbility != "" {
+		return primary
+	}
+
+	best := ""
+	bestLevel := 0
+	levels := c.ClassLevelBreakdown()
+
+	for _, class := range c.ClassNames() {
+		data := AllClassesData[class]
+		if data.SpellcastingAbility != "" && levels[class] > bestLevel {
+			best = class
+			bestLevel = levels[class]
+		}
+	}
+
+	return best
+}
+
+// casterLevel returns the character's level on the multiclass spellcaster
+// table. Pact Magic levels don't count towards it.
+func (c *Character) casterLevel() int {
+	total := 0
+	for class, lvl := range c.ClassLevelBreakdown() {
+		switch AllClassesData[class].CasterProgression {
+		case FullCaster:
+			total += lvl
+		case HalfCaster:
+			total += lvl / 2
+		}
+	}
+	return total
+}
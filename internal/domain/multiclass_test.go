@@ -0,0 +1,122 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"maps"
+	"testing"
+)
+
+func TestMulticlassSpellSlots(t *testing.T) {
+	tests := []struct {
+		name      string
+		class     string
+		levels    map[string]int
+		wantSlots map[int]int
+	}{
+		{name: "SingleFullCaster", class: "wizard", levels: map[string]int{"wizard": 5}, wantSlots: map[int]int{1: 4, 2: 3, 3: 2}},
+		{name: "FighterWizard", class: "fighter", levels: map[string]int{"fighter": 2, "wizard": 3}, wantSlots: map[int]int{1: 4, 2: 2}},
+		{name: "PaladinSorcerer", class: "paladin", levels: map[string]int{"paladin": 2, "sorcerer": 3}, wantSlots: map[int]int{1: 4, 2: 3}},
+		{name: "PaladinRanger", class: "paladin", levels: map[string]int{"paladin": 3, "ranger": 3}, wantSlots: map[int]int{1: 3}},
+		{name: "WarlockWizard", class: "warlock", levels: map[string]int{"warlock": 3, "wizard": 2}, wantSlots: map[int]int{1: 3, 2: 2}},
+		{name: "WarlockOnly", class: "warlock", levels: map[string]int{"warlock": 5}, wantSlots: map[int]int{3: 3}},
+		{name: "NoCasters", class: "fighter", levels: map[string]int{"fighter": 3, "rogue": 2}, wantSlots: map[int]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, Class: tt.class, ClassLevels: tt.levels}
+			char.CalculateMaxSpellSlots()
+
+			// Cantrips known are kept under level 0
+			slots := maps.Clone(char.MaxSpellSlots)
+			delete(slots, 0)
+			for level, count := range slots {
+				if count == 0 {
+					delete(slots, level)
+				}
+			}
+
+			if !maps.Equal(slots, tt.wantSlots) {
+				t.Errorf("spell slots = %v, expected %v", slots, tt.wantSlots)
+			}
+		})
+	}
+}
+
+func TestMulticlassPrereqs(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    string
+		scores   map[string]int
+		newClass string
+		wantErr  bool
+	}{
+		{name: "MeetsBoth", class: "fighter", scores: map[string]int{"STR": 13, "INT": 13}, newClass: "wizard"},
+		{name: "NewClassTooLow", class: "fighter", scores: map[string]int{"STR": 13, "INT": 12}, newClass: "wizard", wantErr: true},
+		{name: "CurrentClassTooLow", class: "fighter", scores: map[string]int{"STR": 12, "DEX": 12, "INT": 15}, newClass: "wizard", wantErr: true},
+		{name: "AnyOfMet", class: "wizard", scores: map[string]int{"DEX": 13, "INT": 13}, newClass: "fighter"},
+		{name: "AllOfMissingOne", class: "fighter", scores: map[string]int{"STR": 15, "CHA": 12}, newClass: "paladin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			abilities := make(map[string]domain.Ability)
+			for ab, score := range tt.scores {
+				abilities[ab] = domain.Ability{Score: score}
+			}
+			char := &domain.Character{
+				Name:          tt.name,
+				Class:         tt.class,
+				Level:         1,
+				ClassLevels:   map[string]int{tt.class: 1},
+				AbilityScores: abilities,
+			}
+
+			err := char.AddClassLevel(tt.newClass)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddClassLevel error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err == nil && char.ClassLevels[tt.newClass] != 1 {
+				t.Errorf("%s level = %d, expected 1", tt.newClass, char.ClassLevels[tt.newClass])
+			}
+			if err != nil && char.IsMulticlassed() {
+				t.Errorf("expected a failed multiclass to leave the class levels alone, got %s", char.ClassSummary())
+			}
+		})
+	}
+}
+
+func TestMulticlassHitPoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		class  string
+		levels map[string]int
+		con    int
+		wantHP int
+	}{
+		// 10 + 6 for the fighter levels, 4 + 4 + 4 for wizard, +2 CON each
+		{name: "FighterFirst", class: "fighter", levels: map[string]int{"fighter": 2, "wizard": 3}, con: 14, wantHP: 38},
+		// 6 + 4 + 4 for wizard, 6 + 6 for fighter, +2 CON each
+		{name: "WizardFirst", class: "wizard", levels: map[string]int{"fighter": 2, "wizard": 3}, con: 14, wantHP: 36},
+		// Each level grants at least 1 hit point despite the CON penalty
+		{name: "MinimumPerLevel", class: "wizard", levels: map[string]int{"wizard": 1, "sorcerer": 2}, con: 1, wantHP: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			con := domain.Ability{Score: tt.con}
+			con.CalculateModifier()
+			char := &domain.Character{
+				Name:          tt.name,
+				Class:         tt.class,
+				ClassLevels:   tt.levels,
+				AbilityScores: map[string]domain.Ability{"CON": con},
+			}
+			char.CalculateMaxHitPoints()
+
+			if char.MaxHitPoints != tt.wantHP {
+				t.Errorf("max HP = %d, expected %d", char.MaxHitPoints, tt.wantHP)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreparingClass works out which class a spell is being prepared for. With no
+// class given it is the first class that prepares spells and has the spell on
+// its list, preferring one that doesn't need it in a spellbook first.
+func (c *Character) PreparingClass(class string, spell Spell) (string, error) {
+	if class != "" {
+		return strings.ToLower(class), nil
+	}
+
+	var candidates []string
+	prepares := false
+	for _, className := range c.ClassNames() {
+		if AllClassesData[className].SpellType != PreparedCasting {
+			continue
+		}
+		prepares = true
+
+		if spell.IsClassSpell(className) {
+			candidates = append(candidates, className)
+		}
+	}
+
+	if !prepares {
+		return "", fmt.Errorf("character '%s' has no class that prepares spells", c.Name)
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("'%s' is not on the spell list of any class character '%s' prepares spells for", spell.Name, c.Name)
+	}
+
+	for _, className := range candidates {
+		if !AllClassesData[className].UsesSpellbook || c.HasInSpellbook(spell.Name) {
+			return className, nil
+		}
+	}
+	return candidates[0], nil
+}
+
+// PreparationLimit is how many spells the class can have prepared: half its
+// level plus its spellcasting modifier, at least one.
+func (c *Character) PreparationLimit(class string) int {
+	className := strings.ToLower(class)
+	ability := AllClassesData[className].SpellcastingAbility
+
+	return max(1, c.ClassLevelBreakdown()[className]/2+c.AbilityScores[ability].Modifier)
+}
+
+// PreparedSpellCount counts the spells prepared for the given class. Spells
+// prepared before the class was recorded count for every class whose list
+// they are on.
+func (c *Character) PreparedSpellCount(class string) int {
+	className := strings.ToLower(class)
+
+	count := 0
+	for key, spell := range c.PreparedSpells {
+		preparedFor, ok := c.PreparedSpellClasses[key]
+		if (ok && preparedFor == className) || (!ok && spell.IsClassSpell(className)) {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckCanPrepareSpell validates preparing a spell for the given class: the
+// class must prepare spells and have the spell on its list and slots of its
+// level, wizards need the spell in their spellbook, and the class's
+// preparation limit must not be reached.
+func (c *Character) CheckCanPrepareSpell(class string, spell Spell) error {
+	className := strings.ToLower(class)
+	data := AllClassesData[className]
+
+	switch data.SpellType {
+	case NoSpellcasting:
+		return fmt.Errorf("this class can't cast spells")
+	case LearnedCasting:
+		return fmt.Errorf("this class learns spells and can't prepare them")
+	}
+
+	classLevel, ok := c.ClassLevelBreakdown()[className]
+	if !ok {
+		return fmt.Errorf("character '%s' has no levels in %s", c.Name, className)
+	}
+
+	if !spell.IsClassSpell(className) {
+		return fmt.Errorf("character class '%s' is not listed as a caster for spell '%s'", className, spell.Name)
+	}
+
+	if highest := c.HighestSpellLevel(className); spell.Level > highest {
+		return fmt.Errorf("'%s' is level %d, but a level %d %s can only cast spells up to level %d",
+			spell.Name, spell.Level, classLevel, className, highest)
+	}
+
+	if data.UsesSpellbook && spell.Level > 0 && !c.HasInSpellbook(spell.Name) {
+		return fmt.Errorf("'%s' is not in %s's spellbook; add it with the spellbook command first", spell.Name, c.Name)
+	}
+
+	if _, ok := c.PreparedSpells[strings.ToLower(spell.Name)]; ok {
+		return fmt.Errorf("spell '%s' is already prepared", spell.Name)
+	}
+
+	if limit := c.PreparationLimit(className); c.PreparedSpellCount(className) >= limit {
+		ability := data.SpellcastingAbility
+		return fmt.Errorf("character '%s' has reached the limit of %d prepared %s spells (Lvl %d + %s Mod %+d)",
+			c.Name, limit, className, classLevel, ability, c.AbilityScores[ability].Modifier)
+	}
+
+	return nil
+}
+
+// AddPreparedSpell records a spell as prepared for the given class.
+func (c *Character) AddPreparedSpell(class string, spell Spell) {
+	key := strings.ToLower(spell.Name)
+
+	if c.PreparedSpells == nil {
+		c.PreparedSpells = make(map[string]Spell)
+	}
+	if c.PreparedSpellClasses == nil {
+		c.PreparedSpellClasses = make(map[string]string)
+	}
+
+	c.PreparedSpells[key] = spell
+	c.PreparedSpellClasses[key] = strings.ToLower(class)
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ShieldProficiency is the armor proficiency for shields; the others are the
+// lowercase armor categories.
+const ShieldProficiency = "shields"
+
+// UpdateEquipmentProficiencies rebuilds the character's weapon, armor and
+// tool proficiencies from their race and classes. Only the starting class
+// grants its full set; classes taken later grant their multiclass ones.
+func (c *Character) UpdateEquipmentProficiencies() {
+	c.WeaponProficiencies = make(ProficiencySet)
+	c.ArmorProficiencies = make(ProficiencySet)
+	c.ToolProficiencies = make(ProficiencySet)
+
+	grant := func(set ProficiencySet, profs []string) {
+		for _, prof := range profs {
+			set[strings.ToLower(prof)] = true
+		}
+	}
+
+	race := AllRaces[strings.ToLower(c.Race)]
+	grant(c.WeaponProficiencies, race.WeaponProficiencies)
+	grant(c.ArmorProficiencies, race.ArmorProficiencies)
+	grant(c.ToolProficiencies, race.ToolProficiencies)
+
+	primary := strings.ToLower(c.Class)
+	for class := range c.ClassLevelBreakdown() {
+		data := AllClassesData[class]
+		if class == primary {
+			grant(c.WeaponProficiencies, data.WeaponProficiencies)
+			grant(c.ArmorProficiencies, data.ArmorProficiencies)
+			grant(c.ToolProficiencies, data.ToolProficiencies)
+			continue
+		}
+		grant(c.WeaponProficiencies, data.MulticlassWeapons)
+		grant(c.ArmorProficiencies, data.MulticlassArmor)
+		grant(c.ToolProficiencies, data.MulticlassTools)
+	}
+}
+
+// ArmorCategory is the armor's proficiency: "light", "medium" or "heavy".
+func (a Armor) ArmorCategory() string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(a.Category), " Armor"))
+}
+
+func (c *Character) IsProficientWithArmor(armor Armor) bool {
+	return c.ArmorProficiencies[armor.ArmorCategory()]
+}
+
+func (c *Character) IsProficientWithShield() bool {
+	return c.ArmorProficiencies[ShieldProficiency]
+}
+
+func (c *Character) IsProficientWithTool(tool string) bool {
+	return c.ToolProficiencies[strings.ToLower(strings.TrimSpace(tool))]
+}
+
+// WearingNonProficientArmor reports whether the equipped armor or shield is
+// one the character isn't proficient with. Such armor gives disadvantage on
+// STR and DEX checks and prevents spellcasting.
+func (c *Character) WearingNonProficientArmor() bool {
+	if c.EquippedArmor.Name != "" && !c.IsProficientWithArmor(c.EquippedArmor) {
+		return true
+	}
+	return c.EquippedShield.Name != "" && !c.IsProficientWithShield()
+}
+
+// HasDisadvantageOnChecks reports whether ability checks with the ability
+// are rolled with disadvantage.
+func (c *Character) HasDisadvantageOnChecks(ability string) bool {
+	return c.CheckBreakdown(strings.ToUpper(ability)).HasDisadvantage()
+}
+
+// CheckEquippedProficiency returns an error when the character isn't
+// proficient with the item they have equipped in the slot.
+func (c *Character) CheckEquippedProficiency(slot string) error {
+	var item string
+
+	switch normalizeItemName(slot) {
+	case "main hand":
+		if w := c.EquippedWeaponMainHand; w.Name != "" && !c.IsProficientWithWeapon(w) {
+			item = w.Name
+		}
+	case "off hand":
+		if w := c.EquippedWeaponOffHand; w.Name != "" && !c.IsProficientWithWeapon(w) {
+			item = w.Name
+		}
+	case "armor":
+		if a := c.EquippedArmor; a.Name != "" && !c.IsProficientWithArmor(a) {
+			return fmt.Errorf("character '%s' isn't proficient with %s armor: disadvantage on STR and DEX checks and no spellcasting while wearing %s",
+				c.Name, a.ArmorCategory(), a.Name)
+		}
+	case "shield":
+		if c.EquippedShield.Name != "" && !c.IsProficientWithShield() {
+			return fmt.Errorf("character '%s' isn't proficient with shields: disadvantage on STR and DEX checks and no spellcasting while holding one", c.Name)
+		}
+	}
+
+	if item != "" {
+		return fmt.Errorf("character '%s' isn't proficient with %s: no proficiency bonus to attack rolls", c.Name, item)
+	}
+	return nil
+}
+
+// ProficiencySet holds lowercase weapon, armor or tool proficiencies.
+type ProficiencySet map[string]bool
+
+// Sorted lists the proficiencies alphabetically.
+func (p ProficiencySet) Sorted() []string {
+	var profs []string
+	for prof, ok := range p {
+		if ok {
+			profs = append(profs, prof)
+		}
+	}
+	sort.Strings(profs)
+	return profs
+}
+
+func (p ProficiencySet) String() string {
+	return strings.Join(p.Sorted(), ", ")
+}
@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestMulticlassProficiencies(t *testing.T) {
+	plate := domain.Armor{Equipment: domain.Equipment{Name: "plate armor", Category: "Heavy"}}
+	breastplate := domain.Armor{Equipment: domain.Equipment{Name: "breastplate", Category: "Medium"}}
+	leather := domain.Armor{Equipment: domain.Equipment{Name: "leather armor", Category: "Light"}}
+	longsword := domain.Weapon{Equipment: domain.Equipment{Name: "longsword", Category: "Martial Melee"}}
+
+	tests := []struct {
+		name          string
+		class         string
+		levels        map[string]int
+		wantPlate     bool
+		wantMedium    bool
+		wantLight     bool
+		wantShield    bool
+		wantLongsword bool
+		wantTools     bool
+	}{
+		{name: "WizardFighter", class: "wizard", levels: map[string]int{"wizard": 5, "fighter": 1},
+			wantMedium: true, wantLight: true, wantShield: true, wantLongsword: true},
+		{name: "FighterWizard", class: "fighter", levels: map[string]int{"fighter": 1, "wizard": 1},
+			wantPlate: true, wantMedium: true, wantLight: true, wantShield: true, wantLongsword: true},
+		{name: "RogueWizard", class: "rogue", levels: map[string]int{"rogue": 1, "wizard": 1},
+			wantLight: true, wantLongsword: true, wantTools: true},
+		// Multiclassing into rogue grants light armor and thieves' tools, but none of the rogue weapons
+		{name: "WizardRogue", class: "wizard", levels: map[string]int{"wizard": 3, "rogue": 1},
+			wantLight: true, wantTools: true},
+		{name: "WizardSorcerer", class: "wizard", levels: map[string]int{"wizard": 3, "sorcerer": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, Race: "human", Class: tt.class, ClassLevels: tt.levels}
+			char.UpdateEquipmentProficiencies()
+
+			if got := char.IsProficientWithArmor(plate); got != tt.wantPlate {
+				t.Errorf("heavy armor proficiency = %v, expected %v", got, tt.wantPlate)
+			}
+			if got := char.IsProficientWithArmor(breastplate); got != tt.wantMedium {
+				t.Errorf("medium armor proficiency = %v, expected %v", got, tt.wantMedium)
+			}
+			if got := char.IsProficientWithArmor(leather); got != tt.wantLight {
+				t.Errorf("light armor proficiency = %v, expected %v", got, tt.wantLight)
+			}
+			if got := char.IsProficientWithShield(); got != tt.wantShield {
+				t.Errorf("shield proficiency = %v, expected %v", got, tt.wantShield)
+			}
+			if got := char.IsProficientWithWeapon(longsword); got != tt.wantLongsword {
+				t.Errorf("longsword proficiency = %v, expected %v", got, tt.wantLongsword)
+			}
+			if got := char.IsProficientWithTool("thieves' tools"); got != tt.wantTools {
+				t.Errorf("thieves' tools proficiency = %v, expected %v", got, tt.wantTools)
+			}
+		})
+	}
+}
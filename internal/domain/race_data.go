@@ -3,49 +3,81 @@ package domain
 type RaceData struct {
 	AbilityScoreIncreases map[string]int
 	SkillProficiency      string
+
+	// Speed is the base walking speed in feet. HeavyArmorSpeed means heavy
+	// armor doesn't slow the race down, even without the Strength for it.
+	Speed           int
+	HeavyArmorSpeed bool
+
+	WeaponProficiencies []string
+	ArmorProficiencies  []string
+	ToolProficiencies   []string
+
+	// Effects are racial traits that change derived statistics
+	Effects []Effect
 }
 
+// DefaultSpeed is the walking speed of races that don't list one.
+const DefaultSpeed = 30
+
 var AllRaces = map[string]RaceData{
 	"human": {
 		AbilityScoreIncreases: map[string]int{
 			"STR": 1, "DEX": 1, "CON": 1, "INT": 1, "WIS": 1, "CHA": 1,
 		},
 		SkillProficiency: "",
+		Speed:            30,
 	},
 	"lightfoot halfling": {
 		AbilityScoreIncreases: map[string]int{
 			"DEX": 2, "CHA": 1,
 		},
 		SkillProficiency: "",
+		Speed:            25,
 	},
 	"high elf": {
 		AbilityScoreIncreases: map[string]int{
 			"DEX": 2, "INT": 1,
 		},
-		SkillProficiency: "Perception",
+		SkillProficiency:    "Perception",
+		Speed:               30,
+		WeaponProficiencies: []string{"longsword", "shortsword", "shortbow", "longbow"},
 	},
 	"half orc": {
 		AbilityScoreIncreases: map[string]int{
 			"STR": 2, "CON": 1,
 		},
 		SkillProficiency: "Intimidation",
+		Speed:            30,
 	},
 	"hill dwarf": {
 		AbilityScoreIncreases: map[string]int{
 			"CON": 2, "WIS": 1,
 		},
-		SkillProficiency: "History",
+		SkillProficiency:    "History",
+		Speed:               25,
+		HeavyArmorSpeed:     true,
+		WeaponProficiencies: []string{"battleaxe", "handaxe", "light hammer", "warhammer"},
+		ToolProficiencies:   []string{"smith's tools"},
+		// Dwarven Toughness
+		Effects: []Effect{{Stat: StatMaxHitPoints, Kind: EffectAdd, Value: 1, PerLevel: true}},
 	},
 	"dwarf": {
 		AbilityScoreIncreases: map[string]int{
 			"CON": 2, "WIS": 0,
 		},
-		SkillProficiency: "History",
+		SkillProficiency:    "History",
+		Speed:               25,
+		HeavyArmorSpeed:     true,
+		WeaponProficiencies: []string{"battleaxe", "handaxe", "light hammer", "warhammer"},
+		ToolProficiencies:   []string{"smith's tools"},
 	},
 	"gnome": {
 		AbilityScoreIncreases: map[string]int{
 			"INT": 2,
 		},
-		SkillProficiency: "",
+		SkillProficiency:  "",
+		Speed:             25,
+		ToolProficiencies: []string{"tinker's tools"},
 	},
 }
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// HitDicePool counts hit dice by die size.
+type HitDicePool map[int]int
+
+// HitDiceTotal is the character's full hit dice pool: one die per level in
+// each class.
+func (c *Character) HitDiceTotal() HitDicePool {
+	pool := make(HitDicePool)
+	for class, lvl := range c.ClassLevelBreakdown() {
+		pool[classHitDie(class)] += lvl
+	}
+	return pool
+}
+
+func (c *Character) HitDiceRemaining() HitDicePool {
+	remaining := make(HitDicePool)
+	for size, total := range c.HitDiceTotal() {
+		if left := total - c.HitDiceSpent[size]; left > 0 {
+			remaining[size] = left
+		}
+	}
+	return remaining
+}
+
+// NextHitDie picks the largest remaining hit die to spend.
+func (c *Character) NextHitDie() (int, bool) {
+	largest := 0
+	for size := range c.HitDiceRemaining() {
+		if size > largest {
+			largest = size
+		}
+	}
+	return largest, largest > 0
+}
+
+// SpendHitDie spends one hit die of the given size during a short rest and
+// regains the rolled amount plus the CON modifier (minimum 0) as hit points.
+func (c *Character) SpendHitDie(size, roll int) (int, error) {
+	if c.Dead {
+		return 0, fmt.Errorf("character '%s' is dead and can't rest", c.Name)
+	}
+	if c.HitDiceRemaining()[size] == 0 {
+		return 0, fmt.Errorf("character '%s' has no d%d hit dice left", c.Name, size)
+	}
+
+	if c.HitDiceSpent == nil {
+		c.HitDiceSpent = make(map[int]int)
+	}
+	c.HitDiceSpent[size]++
+
+	amount := max(roll+c.AbilityScores["CON"].Modifier, 0)
+	return c.Heal(amount)
+}
+
+// FinishShortRest recharges the features that recover on a short rest and
+// the Pact Magic slots.
+func (c *Character) FinishShortRest() error {
+	if c.Dead {
+		return fmt.Errorf("character '%s' is dead and can't rest", c.Name)
+	}
+
+	c.PassRounds(ShortRestRounds)
+	c.rechargeFeatures(ShortRest)
+	c.recoverSpellSlots(ShortRest)
+	return nil
+}
+
+// FinishLongRest restores all hit points, recovers up to half of the total
+// hit dice (at least one), recharges every feature and spell slot and lowers
+// exhaustion by one level. The character must have at least 1 hit point to
+// benefit from a long rest.
+func (c *Character) FinishLongRest() error {
+	if c.Dead {
+		return fmt.Errorf("character '%s' is dead and can't rest", c.Name)
+	}
+	if c.CurrentHitPoints == 0 {
+		return fmt.Errorf("character '%s' needs at least 1 hit point to benefit from a long rest", c.Name)
+	}
+
+	c.PassRounds(LongRestRounds)
+	if c.HasCondition(Exhaustion) {
+		c.RemoveCondition(Exhaustion)
+		c.CalculateMaxHitPoints()
+	}
+
+	c.CurrentHitPoints = c.MaxHitPoints
+	c.TemporaryHitPoints = 0
+	c.resetDeathSaves()
+
+	c.recoverHitDice(max(c.Level/2, 1))
+	c.rechargeFeatures(LongRest)
+	c.recoverSpellSlots(LongRest)
+
+	return nil
+}
+
+// recoverHitDice regains spent hit dice, largest first.
+func (c *Character) recoverHitDice(count int) {
+	var sizes []int
+	for size := range c.HitDiceSpent {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	for _, size := range sizes {
+		recovered := min(count, c.HitDiceSpent[size])
+		c.HitDiceSpent[size] -= recovered
+		count -= recovered
+
+		if c.HitDiceSpent[size] <= 0 {
+			delete(c.HitDiceSpent, size)
+		}
+	}
+}
+
+// rechargeFeatures restores uses of limited features. A long rest also
+// recovers everything that recharges on a short rest.
+func (c *Character) rechargeFeatures(rest RestType) {
+	for key, feature := range c.Features {
+		if feature.Recharge == rest || rest == LongRest {
+			feature.UsesRemaining = feature.MaxUses
+			c.Features[key] = feature
+		}
+	}
+}
+
+// String formats the pool as e.g. "3d10 + 2d6", largest die first.
+func (pool HitDicePool) String() string {
+	var sizes []int
+	for size, count := range pool {
+		if count > 0 {
+			sizes = append(sizes, size)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	if len(sizes) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(sizes))
+	for i, size := range sizes {
+		parts[i] = fmt.Sprintf("%dd%d", pool[size], size)
+	}
+	return strings.Join(parts, " + ")
+}
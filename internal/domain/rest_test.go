@@ -0,0 +1,146 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestSpendHitDie(t *testing.T) {
+	tests := []struct {
+		name       string
+		con        int
+		size       int
+		roll       int
+		wantHealed int
+		wantErr    bool
+	}{
+		{name: "AddsCON", con: 14, size: 10, roll: 3, wantHealed: 5},
+		{name: "NeverNegative", con: 4, size: 10, roll: 1, wantHealed: 0},
+		{name: "SmallerDie", con: 10, size: 6, roll: 4, wantHealed: 4},
+		{name: "NoDieOfThatSize", con: 10, size: 8, roll: 4, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			con := domain.Ability{Score: tt.con}
+			con.CalculateModifier()
+			char := &domain.Character{
+				Name:             tt.name,
+				Class:            "fighter",
+				Level:            2,
+				ClassLevels:      map[string]int{"fighter": 1, "wizard": 1},
+				AbilityScores:    map[string]domain.Ability{"CON": con},
+				MaxHitPoints:     20,
+				CurrentHitPoints: 10,
+			}
+
+			healed, err := char.SpendHitDie(tt.size, tt.roll)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SpendHitDie error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if healed != tt.wantHealed || char.CurrentHitPoints != 10+tt.wantHealed {
+				t.Errorf("healed %d to %d HP, expected %d", healed, char.CurrentHitPoints, tt.wantHealed)
+			}
+			if char.HitDiceRemaining()[tt.size] != 0 {
+				t.Errorf("expected the only d%d to be spent, %v left", tt.size, char.HitDiceRemaining())
+			}
+			if _, err := char.SpendHitDie(tt.size, tt.roll); err == nil {
+				t.Errorf("expected an error spending a d%d with none left", tt.size)
+			}
+		})
+	}
+}
+
+func TestLongRestRecoversHitDice(t *testing.T) {
+	tests := []struct {
+		name      string
+		levels    map[string]int
+		spent     map[int]int
+		wantSpent domain.HitDicePool
+	}{
+		{name: "AtLeastOne", levels: map[string]int{"fighter": 1}, spent: map[int]int{10: 1}, wantSpent: domain.HitDicePool{}},
+		{name: "HalfRoundedDown", levels: map[string]int{"fighter": 5}, spent: map[int]int{10: 5}, wantSpent: domain.HitDicePool{10: 3}},
+		{name: "LargestFirst", levels: map[string]int{"fighter": 3, "wizard": 2}, spent: map[int]int{10: 3, 6: 2}, wantSpent: domain.HitDicePool{10: 1, 6: 2}},
+		{name: "OnlyWhatWasSpent", levels: map[string]int{"fighter": 3, "wizard": 3}, spent: map[int]int{6: 1}, wantSpent: domain.HitDicePool{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := 0
+			for _, lvl := range tt.levels {
+				level += lvl
+			}
+			char := &domain.Character{
+				Name:             tt.name,
+				Class:            "fighter",
+				Level:            level,
+				ClassLevels:      tt.levels,
+				MaxHitPoints:     30,
+				CurrentHitPoints: 1,
+				HitDiceSpent:     tt.spent,
+			}
+
+			if err := char.FinishLongRest(); err != nil {
+				t.Fatalf("Failed to rest: %v", err)
+			}
+
+			if got := domain.HitDicePool(char.HitDiceSpent).String(); got != tt.wantSpent.String() {
+				t.Errorf("spent hit dice = %s, expected %s", got, tt.wantSpent)
+			}
+			if char.CurrentHitPoints != 30 {
+				t.Errorf("HP = %d, expected 30", char.CurrentHitPoints)
+			}
+		})
+	}
+
+	char := &domain.Character{Name: "Down", Class: "fighter", Level: 1, MaxHitPoints: 10}
+	if err := char.FinishLongRest(); err == nil {
+		t.Errorf("expected an error taking a long rest at 0 hit points")
+	}
+}
+
+func TestRestRechargesFeatures(t *testing.T) {
+	newChar := func() *domain.Character {
+		return &domain.Character{
+			Name:             "Rested",
+			Class:            "fighter",
+			Level:            2,
+			ClassLevels:      map[string]int{"fighter": 2},
+			MaxHitPoints:     20,
+			CurrentHitPoints: 20,
+			Features: map[string]domain.CharacterFeature{
+				"fighter:second wind":  {Name: "Second Wind", Class: "fighter", MaxUses: 1, Recharge: domain.ShortRest},
+				"fighter:action surge": {Name: "Action Surge", Class: "fighter", MaxUses: 1, Recharge: domain.ShortRest},
+				"fighter:indomitable":  {Name: "Indomitable", Class: "fighter", MaxUses: 2, Recharge: domain.LongRest},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		rest func(*domain.Character) error
+		want map[string]int
+	}{
+		{name: "ShortRest", rest: (*domain.Character).FinishShortRest, want: map[string]int{"Second Wind": 1, "Action Surge": 1, "Indomitable": 0}},
+		{name: "LongRest", rest: (*domain.Character).FinishLongRest, want: map[string]int{"Second Wind": 1, "Action Surge": 1, "Indomitable": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newChar()
+			if err := tt.rest(char); err != nil {
+				t.Fatalf("Failed to rest: %v", err)
+			}
+
+			for _, feature := range char.FeatureList() {
+				if feature.UsesRemaining != tt.want[feature.Name] {
+					t.Errorf("%s has %d uses, expected %d", feature.Name, feature.UsesRemaining, tt.want[feature.Name])
+				}
+			}
+		})
+	}
+}
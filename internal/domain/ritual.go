@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RitualExtraTime is added to a spell's casting time when cast as a ritual.
+const RitualExtraTime = "10 minutes"
+
+// ritualSpell finds a ritual spell the class can cast: wizards from their
+// spellbook, clerics and druids from their prepared spells and bards from
+// their known spells.
+func (c *Character) ritualSpell(class, key string) (Spell, bool) {
+	switch AllClassesData[class].RitualCasting {
+	case RitualsFromSpellbook:
+		entry, ok := c.Spellbook[key]
+		return entry.Spell, ok
+	case RitualsPrepared:
+		spell, ok := c.PreparedSpells[key]
+		return spell, ok
+	case RitualsKnown:
+		spell, ok := c.KnownSpells[key]
+		return spell, ok
+	}
+	return Spell{}, false
+}
+
+// CastRitual casts a spell with the ritual tag without expending a slot. It
+// takes 10 minutes longer than the spell's normal casting time.
+func (c *Character) CastRitual(name string) (SpellCast, error) {
+	if err := c.checkCanCast(); err != nil {
+		return SpellCast{}, err
+	}
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	canCastRituals := false
+
+	for _, class := range c.ClassNames() {
+		if AllClassesData[class].RitualCasting == NoRituals {
+			continue
+		}
+		canCastRituals = true
+
+		spell, ok := c.ritualSpell(class, key)
+		if !ok {
+			continue
+		}
+
+		if !spell.Ritual {
+			return SpellCast{}, fmt.Errorf("'%s' doesn't have the ritual tag", spell.Name)
+		}
+
+		cast := SpellCast{Spell: spell, SlotLevel: 0, Ritual: true}
+		c.startConcentration(&cast)
+		return cast, nil
+	}
+
+	if !canCastRituals {
+		return SpellCast{}, fmt.Errorf("character '%s' has no class that can cast rituals", c.Name)
+	}
+
+	return SpellCast{}, fmt.Errorf("character '%s' can't cast '%s' as a ritual: wizards need it in their spellbook, clerics and druids must have it prepared, bards must know it", c.Name, name)
+}
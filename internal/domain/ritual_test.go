@@ -0,0 +1,74 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestCastRitual(t *testing.T) {
+	detectMagic := domain.Spell{Name: "Detect Magic", Level: 1, Ritual: true, Concentration: true}
+	alarm := domain.Spell{Name: "Alarm", Level: 1, Ritual: true}
+	shield := domain.Spell{Name: "Shield", Level: 1}
+
+	tests := []struct {
+		name      string
+		class     string
+		spellbook []domain.Spell
+		prepared  []domain.Spell
+		known     []domain.Spell
+		spell     string
+		wantErr   bool
+	}{
+		{name: "WizardFromSpellbook", class: "wizard", spellbook: []domain.Spell{alarm}, spell: "alarm"},
+		{name: "WizardNeedNotPrepare", class: "wizard", spellbook: []domain.Spell{detectMagic}, spell: "Detect Magic"},
+		{name: "WizardNotInSpellbook", class: "wizard", prepared: []domain.Spell{alarm}, spell: "alarm", wantErr: true},
+		{name: "ClericPrepared", class: "cleric", prepared: []domain.Spell{detectMagic}, spell: "detect magic"},
+		{name: "ClericNotPrepared", class: "cleric", known: []domain.Spell{detectMagic}, spell: "detect magic", wantErr: true},
+		{name: "BardKnown", class: "bard", known: []domain.Spell{detectMagic}, spell: "detect magic"},
+		{name: "BardUnknown", class: "bard", spell: "detect magic", wantErr: true},
+		{name: "NoRitualTag", class: "wizard", spellbook: []domain.Spell{shield}, spell: "shield", wantErr: true},
+		{name: "NoRitualCasting", class: "sorcerer", known: []domain.Spell{detectMagic}, spell: "detect magic", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:           tt.name,
+				Class:          tt.class,
+				Level:          1,
+				ClassLevels:    map[string]int{tt.class: 1},
+				Spellbook:      make(map[string]domain.SpellbookEntry),
+				PreparedSpells: make(map[string]domain.Spell),
+				KnownSpells:    make(map[string]domain.Spell),
+			}
+			for _, spell := range tt.spellbook {
+				char.Spellbook[strings.ToLower(spell.Name)] = domain.SpellbookEntry{Spell: spell, Source: domain.SpellbookLevelUp}
+			}
+			for _, spell := range tt.prepared {
+				char.PreparedSpells[strings.ToLower(spell.Name)] = spell
+			}
+			for _, spell := range tt.known {
+				char.KnownSpells[strings.ToLower(spell.Name)] = spell
+			}
+
+			cast, err := char.CastRitual(tt.spell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CastRitual error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if !cast.Ritual || cast.SlotLevel != 0 {
+				t.Errorf("cast = %+v, expected a ritual without a slot", cast)
+			}
+			if len(char.ExpendedSpellSlots) != 0 || char.ExpendedPactSlots != 0 {
+				t.Errorf("expected a ritual not to expend spell slots")
+			}
+			if cast.Spell.Concentration && char.ConcentratingOn != cast.Spell.Name {
+				t.Errorf("concentrating on %q, expected %q", char.ConcentratingOn, cast.Spell.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+// Rules are the optional rules a table can switch on.
+type Rules struct {
+	// EquipFromInventory makes equipping take the item out of the character's
+	// inventory, and put back whatever it replaces, instead of creating it.
+	EquipFromInventory bool
+
+	// VariantEncumbrance slows characters down well before they reach their
+	// carrying capacity: by 10 feet past 5 times their Strength score in
+	// pounds and by 20 feet past 10 times it.
+	VariantEncumbrance bool
+
+	// RefuseNonProficientEquipment makes equipping a weapon, armor or shield
+	// the character isn't proficient with an error rather than a warning.
+	RefuseNonProficientEquipment bool
+}
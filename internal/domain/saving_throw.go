@@ -0,0 +1,27 @@
+package domain
+
+import "strings"
+
+// SetClassSavingThrows grants the saving throw proficiencies of the starting
+// class. Classes gained by multiclassing don't add save proficiencies.
+func (c *Character) SetClassSavingThrows() {
+	if c.SavingThrowProficiencies == nil {
+		c.SavingThrowProficiencies = make(map[string]bool)
+	}
+
+	for _, ab := range AllClassesData[strings.ToLower(c.Class)].SavingThrows {
+		c.SavingThrowProficiencies[ab] = true
+	}
+}
+
+func (c *Character) GetSavingThrowModifier(ability string) int {
+	if _, ok := c.AbilityScores[ability]; !ok {
+		return 0
+	}
+
+	return c.SavingThrowBreakdown(ability).Total
+}
+
+func (c *Character) IsProficientInSave(ability string) bool {
+	return c.SavingThrowProficiencies[ability]
+}
@@ -1,11 +1,36 @@
 package domain
 
+import "strings"
+
 type Spell struct {
-	Name   string
-	Level  int
-	Class  []string
-	School string
-	Range  string
+	Name          string
+	Level         int
+	Class         []string
+	School        string
+	Range         string
+	Concentration bool
+	Duration      string
+	Ritual        bool
+	CastingTime   string
+	Components    []string
+	Material      string
+	Description   string
+	HigherLevel   string
 }
 
 var AllSpells map[int][]Spell
+
+// IsComplete reports whether the spell has the details only the compendium or
+// the API provide, so it doesn't need enriching.
+func (s Spell) IsComplete() bool {
+	return s.School != "" && s.Range != "" && s.Description != ""
+}
+
+func (s Spell) IsClassSpell(class string) bool {
+	for _, c := range s.Class {
+		if strings.EqualFold(c, class) {
+			return true
+		}
+	}
+	return false
+}
@@ -35,4 +35,28 @@ var PactCasterSlots = map[int]map[int]int{
 var CantripCount = map[string]map[int]int{
 	"warlock":      {1: 2, 4: 3, 10: 4, 20: 4},
 	"default_full": {1: 3, 4: 4, 10: 5, 20: 5},
+	"sorcerer":     {1: 4, 4: 5, 10: 6, 20: 6},
+	"bard":         {1: 2, 4: 3, 10: 4, 20: 4},
+	"druid":        {1: 2, 4: 3, 10: 4, 20: 4},
+}
+
+// SpellsKnown is the number of spells of 1st level and higher a learned
+// caster knows at each class level.
+var SpellsKnown = map[string]map[int]int{
+	"bard": {
+		1: 4, 2: 5, 3: 6, 4: 7, 5: 8, 6: 9, 7: 10, 8: 11, 9: 12, 10: 14,
+		11: 15, 12: 15, 13: 16, 14: 18, 15: 19, 16: 19, 17: 20, 18: 22, 19: 22, 20: 22,
+	},
+	"ranger": {
+		1: 0, 2: 2, 3: 3, 4: 3, 5: 4, 6: 4, 7: 5, 8: 5, 9: 6, 10: 6,
+		11: 7, 12: 7, 13: 8, 14: 8, 15: 9, 16: 9, 17: 10, 18: 10, 19: 11, 20: 11,
+	},
+	"sorcerer": {
+		1: 2, 2: 3, 3: 4, 4: 5, 5: 6, 6: 7, 7: 8, 8: 9, 9: 10, 10: 11,
+		11: 12, 12: 12, 13: 13, 14: 13, 15: 14, 16: 14, 17: 15, 18: 15, 19: 15, 20: 15,
+	},
+	"warlock": {
+		1: 2, 2: 3, 3: 4, 4: 5, 5: 6, 6: 7, 7: 8, 8: 9, 9: 10, 10: 10,
+		11: 11, 12: 11, 13: 12, 14: 12, 15: 13, 16: 13, 17: 14, 18: 14, 19: 15, 20: 15,
+	},
 }
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const MaxSpellLevel = 9
+
+// PactSlots returns the level and number of Pact Magic slots from warlock levels.
+func (c *Character) PactSlots() (int, int) {
+	for class, lvl := range c.ClassLevelBreakdown() {
+		if AllClassesData[class].CasterProgression != PactCaster {
+			continue
+		}
+
+		for level, count := range PactCasterSlots[lvl] {
+			return level, count
+		}
+	}
+	return 0, 0
+}
+
+// RemainingSpellSlots is the number of unexpended slots of a spell level,
+// counting both Spellcasting and Pact Magic slots.
+func (c *Character) RemainingSpellSlots(level int) int {
+	return c.remainingRegularSlots(level) + c.remainingPactSlots(level)
+}
+
+func (c *Character) remainingRegularSlots(level int) int {
+	total := c.MaxSpellSlots[level]
+	if pactLevel, pactCount := c.PactSlots(); pactLevel == level {
+		total -= pactCount
+	}
+	return max(total-c.ExpendedSpellSlots[level], 0)
+}
+
+func (c *Character) remainingPactSlots(level int) int {
+	pactLevel, pactCount := c.PactSlots()
+	if pactLevel != level {
+		return 0
+	}
+	return max(pactCount-c.ExpendedPactSlots, 0)
+}
+
+// FindCastableSpell looks a spell up among the character's known and
+// prepared spells.
+func (c *Character) FindCastableSpell(name string) (Spell, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+
+	if spell, ok := c.PreparedSpells[key]; ok {
+		return spell, true
+	}
+	if spell, ok := c.KnownSpells[key]; ok {
+		return spell, true
+	}
+	return Spell{}, false
+}
+
+// SpellCast describes a cast: the slot level used (0 for cantrips) and the
+// concentration spell it ended, if any.
+type SpellCast struct {
+	Spell              Spell
+	SlotLevel          int
+	Ritual             bool
+	EndedConcentration string
+}
+
+// CastSpell casts a known or prepared spell, expending a slot of slotLevel.
+// A slotLevel of 0 casts the spell at its own level; higher levels upcast it.
+// Cantrips don't use slots. Pact Magic slots are used before Spellcasting
+// slots since they come back on a short rest. Pact Magic slots are all of one
+// level, so with no slot of the requested level left the spell is upcast
+// with a higher-level Pact Magic slot.
+func (c *Character) CastSpell(name string, slotLevel int) (SpellCast, error) {
+	if err := c.checkCanCast(); err != nil {
+		return SpellCast{}, err
+	}
+
+	spell, ok := c.FindCastableSpell(name)
+	if !ok {
+		return SpellCast{}, fmt.Errorf("character '%s' doesn't know or have prepared the spell '%s'", c.Name, name)
+	}
+
+	cast := SpellCast{Spell: spell}
+
+	if spell.Level > 0 {
+		if slotLevel == 0 {
+			slotLevel = spell.Level
+		}
+
+		if slotLevel < spell.Level {
+			return cast, fmt.Errorf("%s is a level %d spell and can't be cast with a level %d slot", spell.Name, spell.Level, slotLevel)
+		}
+		if slotLevel > MaxSpellLevel {
+			return cast, fmt.Errorf("spell slots only go up to level %d", MaxSpellLevel)
+		}
+
+		pactLevel, _ := c.PactSlots()
+
+		switch {
+		case c.remainingPactSlots(slotLevel) > 0:
+			c.ExpendedPactSlots++
+		case c.remainingRegularSlots(slotLevel) > 0:
+			if c.ExpendedSpellSlots == nil {
+				c.ExpendedSpellSlots = make(map[int]int)
+			}
+			c.ExpendedSpellSlots[slotLevel]++
+		case pactLevel > slotLevel && c.remainingPactSlots(pactLevel) > 0:
+			c.ExpendedPactSlots++
+			slotLevel = pactLevel
+		default:
+			return cast, fmt.Errorf("character '%s' has no level %d spell slots remaining", c.Name, slotLevel)
+		}
+
+		cast.SlotLevel = slotLevel
+	}
+
+	c.startConcentration(&cast)
+	return cast, nil
+}
+
+// checkCanCast rules out casting while unconscious or wearing armor the
+// character isn't proficient with.
+func (c *Character) checkCanCast() error {
+	if c.Dead || (c.CurrentHitPoints == 0 && c.MaxHitPoints > 0) {
+		return fmt.Errorf("character '%s' is unconscious and can't cast spells", c.Name)
+	}
+	if c.IsIncapacitated() {
+		return fmt.Errorf("character '%s' is incapacitated and can't cast spells", c.Name)
+	}
+	if c.WearingNonProficientArmor() {
+		return fmt.Errorf("character '%s' can't cast spells while wearing armor or a shield they aren't proficient with", c.Name)
+	}
+	return nil
+}
+
+func (c *Character) startConcentration(cast *SpellCast) {
+	if cast.Spell.Concentration {
+		cast.EndedConcentration = c.EndConcentration()
+		c.ConcentratingOn = cast.Spell.Name
+	}
+}
+
+// recoverSpellSlots restores Pact Magic slots, and on a long rest every slot.
+func (c *Character) recoverSpellSlots(rest RestType) {
+	c.ExpendedPactSlots = 0
+
+	if rest == LongRest {
+		c.ExpendedSpellSlots = nil
+	}
+}
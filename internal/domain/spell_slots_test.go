@@ -0,0 +1,120 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func newCaster(class string, levels map[string]int) *domain.Character {
+	level := 0
+	for _, lvl := range levels {
+		level += lvl
+	}
+
+	char := &domain.Character{
+		Name:             "Caster",
+		Class:            class,
+		Level:            level,
+		ClassLevels:      levels,
+		MaxHitPoints:     20,
+		CurrentHitPoints: 20,
+		KnownSpells: map[string]domain.Spell{
+			"fire bolt":      {Name: "Fire Bolt"},
+			"magic missile":  {Name: "Magic Missile", Level: 1},
+			"hex":            {Name: "Hex", Level: 1, Concentration: true},
+			"misty step":     {Name: "Misty Step", Level: 2},
+			"hold person":    {Name: "Hold Person", Level: 2, Concentration: true},
+			"lightning bolt": {Name: "Lightning Bolt", Level: 3},
+		},
+	}
+	char.CalculateMaxSpellSlots()
+	return char
+}
+
+func TestCastSpell(t *testing.T) {
+	tests := []struct {
+		name         string
+		class        string
+		levels       map[string]int
+		spell        string
+		slotLevel    int
+		wantSlot     int
+		wantErr      bool
+		wantRegular  map[int]int
+		wantPactUsed int
+	}{
+		{name: "Cantrip", class: "wizard", levels: map[string]int{"wizard": 1}, spell: "fire bolt", wantSlot: 0},
+		{name: "OwnLevel", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "magic missile", wantSlot: 1, wantRegular: map[int]int{1: 1}},
+		{name: "Upcast", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "magic missile", slotLevel: 2, wantSlot: 2, wantRegular: map[int]int{2: 1}},
+		{name: "SlotTooLow", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "misty step", slotLevel: 1, wantErr: true},
+		{name: "NoSlotOfLevel", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "lightning bolt", wantErr: true},
+		{name: "AboveNinth", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "magic missile", slotLevel: 10, wantErr: true},
+		{name: "UnknownSpell", class: "wizard", levels: map[string]int{"wizard": 3}, spell: "wish", wantErr: true},
+		{name: "PactSlotUpcasts", class: "warlock", levels: map[string]int{"warlock": 3}, spell: "hex", wantSlot: 2, wantPactUsed: 1},
+		{name: "PactSlotForLowerRequest", class: "warlock", levels: map[string]int{"warlock": 3}, spell: "hex", slotLevel: 1, wantSlot: 2, wantPactUsed: 1},
+		{name: "PactSlotOwnLevel", class: "warlock", levels: map[string]int{"warlock": 3}, spell: "hold person", wantSlot: 2, wantPactUsed: 1},
+		{name: "PactSlotTooLow", class: "warlock", levels: map[string]int{"warlock": 3}, spell: "lightning bolt", wantErr: true},
+		{name: "RegularSlotBeforeUpcasting", class: "warlock", levels: map[string]int{"warlock": 3, "wizard": 1}, spell: "hex", wantSlot: 1, wantRegular: map[int]int{1: 1}},
+		{name: "PactSlotBeforeRegular", class: "warlock", levels: map[string]int{"warlock": 3, "wizard": 3}, spell: "misty step", wantSlot: 2, wantPactUsed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newCaster(tt.class, tt.levels)
+
+			cast, err := char.CastSpell(tt.spell, tt.slotLevel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CastSpell error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(char.ExpendedSpellSlots) != 0 || char.ExpendedPactSlots != 0 {
+					t.Errorf("expected a failed cast to expend nothing")
+				}
+				return
+			}
+
+			if cast.SlotLevel != tt.wantSlot {
+				t.Errorf("cast with a level %d slot, expected %d", cast.SlotLevel, tt.wantSlot)
+			}
+			for level, want := range tt.wantRegular {
+				if got := char.ExpendedSpellSlots[level]; got != want {
+					t.Errorf("expended level %d slots = %d, expected %d", level, got, want)
+				}
+			}
+			if char.ExpendedPactSlots != tt.wantPactUsed {
+				t.Errorf("expended pact slots = %d, expected %d", char.ExpendedPactSlots, tt.wantPactUsed)
+			}
+		})
+	}
+}
+
+func TestSpellSlotsRunOutAndRecover(t *testing.T) {
+	char := newCaster("warlock", map[string]int{"warlock": 3, "wizard": 1})
+
+	// The wizard's two level 1 slots, then the two level 2 pact slots
+	for i := 0; i < 4; i++ {
+		if _, err := char.CastSpell("magic missile", 0); err != nil {
+			t.Fatalf("cast %d failed: %v", i+1, err)
+		}
+	}
+	if _, err := char.CastSpell("magic missile", 0); err == nil {
+		t.Fatalf("expected an error with every slot expended")
+	}
+
+	if err := char.FinishShortRest(); err != nil {
+		t.Fatalf("Failed to rest: %v", err)
+	}
+	if got := char.RemainingSpellSlots(2); got != 2 {
+		t.Errorf("level 2 slots after a short rest = %d, expected the 2 pact slots back", got)
+	}
+	if got := char.RemainingSpellSlots(1); got != 0 {
+		t.Errorf("level 1 slots after a short rest = %d, expected 0", got)
+	}
+
+	if err := char.FinishLongRest(); err != nil {
+		t.Fatalf("Failed to rest: %v", err)
+	}
+	if got := char.RemainingSpellSlots(1); got != 2 {
+		t.Errorf("level 1 slots after a long rest = %d, expected 2", got)
+	}
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	StartingSpellbookSpells = 6
+	SpellbookSpellsPerLevel = 2
+
+	// Copying a spell costs 50 gp and takes 2 hours per spell level
+	SpellCopyCostPerLevel  = 50
+	SpellCopyHoursPerLevel = 2
+)
+
+// Spell sources for spellbook entries. Spells gained on level up are free,
+// copied ones record their cost and time.
+const (
+	SpellbookLevelUp = "level up"
+	SpellbookScroll  = "scroll"
+	SpellbookCopied  = "spellbook"
+)
+
+type SpellbookEntry struct {
+	Spell  Spell
+	Source string
+	CostGP int
+	Hours  int
+}
+
+// HighestSpellLevel is the highest level of spell slots the given class has
+// on its own at its current level.
+func (c *Character) HighestSpellLevel(class string) int {
+	className := strings.ToLower(class)
+	classLevel := c.ClassLevelBreakdown()[className]
+
+	var slots map[int]int
+	switch AllClassesData[className].CasterProgression {
+	case FullCaster:
+		slots = FullCasterSlots[classLevel]
+	case HalfCaster:
+		slots = HalfCasterSlots[classLevel]
+	case PactCaster:
+		slots = PactCasterSlots[classLevel]
+	}
+
+	highest := 0
+	for level, count := range slots {
+		if count > 0 && level > highest {
+			highest = level
+		}
+	}
+	return highest
+}
+
+// FreeSpellbookSpells is how many spells a wizard adds to their spellbook for
+// free: six at 1st level and two more for each level after.
+func (c *Character) FreeSpellbookSpells(class string) int {
+	classLevel := c.ClassLevelBreakdown()[strings.ToLower(class)]
+	if classLevel < 1 {
+		return 0
+	}
+	return StartingSpellbookSpells + SpellbookSpellsPerLevel*(classLevel-1)
+}
+
+func (c *Character) countSpellbookSource(source string) int {
+	count := 0
+	for _, entry := range c.Spellbook {
+		if entry.Source == source {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Character) HasInSpellbook(spellName string) bool {
+	_, ok := c.Spellbook[strings.ToLower(strings.TrimSpace(spellName))]
+	return ok
+}
+
+// AddToSpellbook writes a class spell into the character's spellbook. With an
+// empty source it is one of the free spells gained on level up; a scroll or
+// another spellbook as the source costs gold and time to copy.
+func (c *Character) AddToSpellbook(class string, spell Spell, source string) (SpellbookEntry, error) {
+	className := strings.ToLower(class)
+	if !AllClassesData[className].UsesSpellbook {
+		return SpellbookEntry{}, fmt.Errorf("the %s class doesn't keep a spellbook", className)
+	}
+
+	if spell.Level == 0 {
+		return SpellbookEntry{}, fmt.Errorf("cantrips aren't written in a spellbook")
+	}
+
+	if !spell.IsClassSpell(className) {
+		return SpellbookEntry{}, fmt.Errorf("'%s' is not a %s spell", spell.Name, className)
+	}
+
+	if c.HasInSpellbook(spell.Name) {
+		return SpellbookEntry{}, fmt.Errorf("'%s' is already in the spellbook", spell.Name)
+	}
+
+	if highest := c.HighestSpellLevel(className); spell.Level > highest {
+		return SpellbookEntry{}, fmt.Errorf("'%s' is level %d, but a level %d %s can only learn spells up to level %d",
+			spell.Name, spell.Level, c.ClassLevelBreakdown()[className], className, highest)
+	}
+
+	entry := SpellbookEntry{Spell: spell, Source: strings.ToLower(strings.TrimSpace(source))}
+
+	switch entry.Source {
+	case "", SpellbookLevelUp:
+		entry.Source = SpellbookLevelUp
+		if free := c.FreeSpellbookSpells(className); c.countSpellbookSource(SpellbookLevelUp) >= free {
+			return SpellbookEntry{}, fmt.Errorf("character '%s' has already added all %d free spells to the spellbook; copy spells from scrolls or other spellbooks", c.Name, free)
+		}
+	case SpellbookScroll, SpellbookCopied:
+		entry.CostGP = SpellCopyCostPerLevel * spell.Level
+		entry.Hours = SpellCopyHoursPerLevel * spell.Level
+	default:
+		return SpellbookEntry{}, fmt.Errorf("unknown spellbook source '%s' (use scroll or spellbook)", source)
+	}
+
+	if c.Spellbook == nil {
+		c.Spellbook = make(map[string]SpellbookEntry)
+	}
+	c.Spellbook[strings.ToLower(spell.Name)] = entry
+
+	return entry, nil
+}
+
+// SpellbookEntries lists the spellbook by spell level, then name.
+func (c *Character) SpellbookEntries() []SpellbookEntry {
+	var entries []SpellbookEntry
+	for _, entry := range c.Spellbook {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Spell.Level != entries[j].Spell.Level {
+			return entries[i].Spell.Level < entries[j].Spell.Level
+		}
+		return entries[i].Spell.Name < entries[j].Spell.Name
+	})
+
+	return entries
+}
@@ -0,0 +1,81 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"fmt"
+	"testing"
+)
+
+func TestAddToSpellbook(t *testing.T) {
+	wizardSpell := func(name string, level int) domain.Spell {
+		return domain.Spell{Name: name, Level: level, Class: []string{"Wizard", "Sorcerer"}}
+	}
+	newWizard := func(level int) *domain.Character {
+		return &domain.Character{Name: "Scribe", Class: "wizard", Level: level, ClassLevels: map[string]int{"wizard": level}}
+	}
+
+	tests := []struct {
+		name      string
+		class     string
+		level     int
+		spell     domain.Spell
+		source    string
+		wantErr   bool
+		wantCost  int
+		wantHours int
+	}{
+		{name: "LevelUp", class: "wizard", level: 1, spell: wizardSpell("Shield", 1)},
+		{name: "Scroll", class: "wizard", level: 1, spell: wizardSpell("Shield", 1), source: "scroll", wantCost: 50, wantHours: 2},
+		{name: "OtherSpellbook", class: "wizard", level: 3, spell: wizardSpell("Misty Step", 2), source: "Spellbook", wantCost: 100, wantHours: 4},
+		{name: "TooHighLevel", class: "wizard", level: 1, spell: wizardSpell("Misty Step", 2), wantErr: true},
+		{name: "TooHighToCopy", class: "wizard", level: 3, spell: wizardSpell("Fireball", 3), source: "scroll", wantErr: true},
+		{name: "Cantrip", class: "wizard", level: 1, spell: wizardSpell("Fire Bolt", 0), wantErr: true},
+		{name: "NotAWizardSpell", class: "wizard", level: 1, spell: domain.Spell{Name: "Cure Wounds", Level: 1, Class: []string{"Cleric"}}, wantErr: true},
+		{name: "NoSpellbook", class: "sorcerer", level: 1, spell: wizardSpell("Shield", 1), wantErr: true},
+		{name: "UnknownSource", class: "wizard", level: 1, spell: wizardSpell("Shield", 1), source: "dream", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newWizard(tt.level)
+
+			entry, err := char.AddToSpellbook(tt.class, tt.spell, tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AddToSpellbook error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if entry.CostGP != tt.wantCost || entry.Hours != tt.wantHours {
+				t.Errorf("copying cost %d gp and %d hours, expected %d gp and %d hours", entry.CostGP, entry.Hours, tt.wantCost, tt.wantHours)
+			}
+			if !char.HasInSpellbook(tt.spell.Name) {
+				t.Errorf("expected %s in the spellbook", tt.spell.Name)
+			}
+			if _, err := char.AddToSpellbook(tt.class, tt.spell, tt.source); err == nil {
+				t.Errorf("expected an error adding %s twice", tt.spell.Name)
+			}
+		})
+	}
+
+	t.Run("FreeSpellLimit", func(t *testing.T) {
+		char := newWizard(2)
+
+		// Six at 1st level and two more at 2nd
+		for i := 1; i <= 8; i++ {
+			if _, err := char.AddToSpellbook("wizard", wizardSpell(fmt.Sprintf("Spell %d", i), 1), ""); err != nil {
+				t.Fatalf("Failed to add free spell %d: %v", i, err)
+			}
+		}
+		if _, err := char.AddToSpellbook("wizard", wizardSpell("Spell 9", 1), ""); err == nil {
+			t.Errorf("expected an error adding a ninth free spell at level 2")
+		}
+		if _, err := char.AddToSpellbook("wizard", wizardSpell("Spell 9", 1), "scroll"); err != nil {
+			t.Errorf("Failed to copy a spell past the free limit: %v", err)
+		}
+		if got := len(char.SpellbookEntries()); got != 9 {
+			t.Errorf("spellbook has %d spells, expected 9", got)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "fmt"
+
+// StartingItem is an item, and how many of it, a class starts with.
+type StartingItem struct {
+	Name  string
+	Count int
+}
+
+// StartingEquipment is the equipment each class starts with, taking the
+// first option wherever the SRD offers a choice.
+var StartingEquipment = map[string][]StartingItem{
+	"barbarian": {{"greataxe", 1}, {"handaxe", 2}, {"explorer's pack", 1}, {"javelin", 4}},
+	"bard":      {{"rapier", 1}, {"diplomat's pack", 1}, {"lute", 1}, {"leather armor", 1}, {"dagger", 1}},
+	"cleric": {{"mace", 1}, {"scale mail", 1}, {"crossbow, light", 1}, {"crossbow bolt", 20},
+		{"priest's pack", 1}, {"shield", 1}, {"amulet", 1}},
+	"druid":   {{"shield", 1}, {"scimitar", 1}, {"leather armor", 1}, {"explorer's pack", 1}, {"sprig of mistletoe", 1}},
+	"fighter": {{"chain mail", 1}, {"longsword", 1}, {"shield", 1}, {"crossbow, light", 1}, {"crossbow bolt", 20}, {"dungeoneer's pack", 1}},
+	"monk":    {{"shortsword", 1}, {"dungeoneer's pack", 1}, {"dart", 10}},
+	"paladin": {{"longsword", 1}, {"shield", 1}, {"javelin", 5}, {"priest's pack", 1}, {"chain mail", 1}, {"amulet", 1}},
+	"ranger": {{"scale mail", 1}, {"shortsword", 2}, {"dungeoneer's pack", 1}, {"longbow", 1},
+		{"quiver", 1}, {"arrow", 20}},
+	"rogue": {{"rapier", 1}, {"shortbow", 1}, {"quiver", 1}, {"arrow", 20}, {"burglar's pack", 1},
+		{"leather armor", 1}, {"dagger", 2}, {"thieves' tools", 1}},
+	"sorcerer": {{"crossbow, light", 1}, {"crossbow bolt", 20}, {"component pouch", 1}, {"dungeoneer's pack", 1}, {"dagger", 2}},
+	"warlock": {{"crossbow, light", 1}, {"crossbow bolt", 20}, {"component pouch", 1}, {"scholar's pack", 1},
+		{"leather armor", 1}, {"quarterstaff", 1}, {"dagger", 2}},
+	"wizard": {{"quarterstaff", 1}, {"component pouch", 1}, {"scholar's pack", 1}, {"spellbook", 1}},
+}
+
+// WealthRoll is a class's starting wealth: Dice d4s times Multiplier gold pieces.
+type WealthRoll struct {
+	Dice       int
+	Multiplier int
+}
+
+// StartingWealth is the gold each class can start with instead of its
+// starting equipment.
+var StartingWealth = map[string]WealthRoll{
+	"barbarian": {Dice: 2, Multiplier: 10},
+	"bard":      {Dice: 5, Multiplier: 10},
+	"cleric":    {Dice: 5, Multiplier: 10},
+	"druid":     {Dice: 2, Multiplier: 10},
+	"fighter":   {Dice: 5, Multiplier: 10},
+	"monk":      {Dice: 5, Multiplier: 1},
+	"paladin":   {Dice: 5, Multiplier: 10},
+	"ranger":    {Dice: 5, Multiplier: 10},
+	"rogue":     {Dice: 4, Multiplier: 10},
+	"sorcerer":  {Dice: 3, Multiplier: 10},
+	"warlock":   {Dice: 4, Multiplier: 10},
+	"wizard":    {Dice: 4, Multiplier: 10},
+}
+
+// Expression is the dice to roll, e.g. "5d4".
+func (w WealthRoll) Expression() string {
+	return fmt.Sprintf("%dd4", w.Dice)
+}
+
+// Gold is the wealth in gold pieces for a roll of the dice.
+func (w WealthRoll) Gold(roll int) int {
+	return roll * w.Multiplier
+}
+
+// AverageGold is the fixed starting wealth for players who don't roll.
+func (w WealthRoll) AverageGold() int {
+	return w.Gold(w.Dice * 5 / 2)
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type SubclassFeature struct {
+	Name     string
+	Subclass string
+	Class    string
+	Level    int
+}
+
+// SetSubclass records the subclass chosen for one of the character's classes.
+// The class must have reached its subclass level, and a choice can't be
+// changed once made.
+func (c *Character) SetSubclass(class, subclass string) error {
+	className := strings.ToLower(class)
+	subclassName := strings.ToLower(strings.TrimSpace(subclass))
+
+	data, ok := AllSubclassesData[subclassName]
+	if !ok {
+		return fmt.Errorf("subclass '%s' not found", subclass)
+	}
+
+	if data.Class != className {
+		return fmt.Errorf("subclass '%s' belongs to %s, not %s", subclassName, data.Class, className)
+	}
+
+	classLevel, ok := c.ClassLevelBreakdown()[className]
+	if !ok {
+		return fmt.Errorf("character '%s' has no levels in %s", c.Name, className)
+	}
+
+	subclassLevel := AllClassesData[className].SubclassLevel
+	if classLevel < subclassLevel {
+		return fmt.Errorf("%s subclasses are chosen at level %d, character is %s level %d",
+			className, subclassLevel, className, classLevel)
+	}
+
+	if existing, ok := c.Subclasses[className]; ok && existing != subclassName {
+		return fmt.Errorf("character '%s' already follows the %s subclass", c.Name, existing)
+	}
+
+	if c.Subclasses == nil {
+		c.Subclasses = make(map[string]string)
+	}
+	c.Subclasses[className] = subclassName
+
+	return nil
+}
+
+// ValidateSubclasses checks that every class that has reached its subclass
+// level has a subclass chosen.
+func (c *Character) ValidateSubclasses() error {
+	levels := c.ClassLevelBreakdown()
+
+	for _, class := range c.ClassNames() {
+		subclassLevel := AllClassesData[class].SubclassLevel
+		if subclassLevel == 0 || levels[class] < subclassLevel {
+			continue
+		}
+
+		if _, ok := c.Subclasses[class]; !ok {
+			return fmt.Errorf("%s level %d requires choosing a subclass (available: %s)",
+				class, levels[class], strings.Join(SubclassesForClass(class), ", "))
+		}
+	}
+
+	return nil
+}
+
+// SubclassNames returns the chosen subclasses in the same order as ClassNames.
+func (c *Character) SubclassNames() []string {
+	var names []string
+	for _, class := range c.ClassNames() {
+		if subclass, ok := c.Subclasses[class]; ok {
+			names = append(names, subclass)
+		}
+	}
+	return names
+}
+
+// SubclassFeatures lists the subclass features the character has unlocked at
+// its current class levels.
+func (c *Character) SubclassFeatures() []SubclassFeature {
+	levels := c.ClassLevelBreakdown()
+
+	var features []SubclassFeature
+	for _, class := range c.ClassNames() {
+		subclass, ok := c.Subclasses[class]
+		if !ok {
+			continue
+		}
+
+		data := AllSubclassesData[subclass]
+
+		var featureLevels []int
+		for lvl := range data.Features {
+			if lvl <= levels[class] {
+				featureLevels = append(featureLevels, lvl)
+			}
+		}
+		sort.Ints(featureLevels)
+
+		for _, lvl := range featureLevels {
+			for _, name := range data.Features[lvl] {
+				features = append(features, SubclassFeature{
+					Name:     name,
+					Subclass: subclass,
+					Class:    class,
+					Level:    lvl,
+				})
+			}
+		}
+	}
+
+	return features
+}
+
+func SubclassesForClass(class string) []string {
+	className := strings.ToLower(class)
+
+	var names []string
+	for name, data := range AllSubclassesData {
+		if data.Class == className {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,146 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestSetSubclass(t *testing.T) {
+	tests := []struct {
+		name         string
+		levels       map[string]int
+		existing     string
+		class        string
+		subclass     string
+		wantErr      bool
+		wantSubclass string
+	}{
+		{name: "AtSubclassLevel", levels: map[string]int{"fighter": 3}, class: "fighter", subclass: "champion", wantSubclass: "champion"},
+		{name: "WrongClass", levels: map[string]int{"fighter": 3}, class: "fighter", subclass: "thief", wantErr: true},
+		{name: "BeforeSubclassLevel", levels: map[string]int{"fighter": 2}, class: "fighter", subclass: "champion", wantErr: true},
+		{name: "ClassNotTaken", levels: map[string]int{"fighter": 3}, class: "wizard", subclass: "school of evocation", wantErr: true},
+		{name: "SameChoiceAgain", levels: map[string]int{"fighter": 4}, existing: "champion", class: "fighter", subclass: "Champion", wantSubclass: "champion"},
+		// The SRD has one subclass per class, so an older choice stands in for a different one
+		{name: "ChangeExisting", levels: map[string]int{"fighter": 4}, existing: "battle master", class: "fighter", subclass: "champion", wantErr: true, wantSubclass: "battle master"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, Class: "fighter", ClassLevels: tt.levels}
+			if tt.existing != "" {
+				char.Subclasses = map[string]string{"fighter": tt.existing}
+			}
+
+			err := char.SetSubclass(tt.class, tt.subclass)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetSubclass error = %v, expected error: %v", err, tt.wantErr)
+			}
+			if got := char.Subclasses[tt.class]; got != tt.wantSubclass {
+				t.Errorf("%s subclass = %q, expected %q", tt.class, got, tt.wantSubclass)
+			}
+		})
+	}
+}
+
+func TestValidateSubclasses(t *testing.T) {
+	tests := []struct {
+		name       string
+		class      string
+		levels     map[string]int
+		subclasses map[string]string
+		wantErr    bool
+	}{
+		{name: "BelowSubclassLevel", class: "fighter", levels: map[string]int{"fighter": 2}},
+		{name: "AtSubclassLevelMissing", class: "fighter", levels: map[string]int{"fighter": 3}, wantErr: true},
+		{name: "AtSubclassLevelChosen", class: "fighter", levels: map[string]int{"fighter": 3}, subclasses: map[string]string{"fighter": "champion"}},
+		{name: "FirstLevelSubclassMissing", class: "sorcerer", levels: map[string]int{"sorcerer": 1}, wantErr: true},
+		{name: "MulticlassMissing", class: "fighter", levels: map[string]int{"fighter": 3, "wizard": 2}, subclasses: map[string]string{"fighter": "champion"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{Name: tt.name, Class: tt.class, ClassLevels: tt.levels, Subclasses: tt.subclasses}
+
+			if err := char.ValidateSubclasses(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSubclasses error = %v, expected error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubclassFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		class    string
+		subclass string
+		level    int
+		want     []string
+	}{
+		{name: "ChampionAt3", class: "fighter", subclass: "champion", level: 3, want: []string{"Improved Critical"}},
+		{name: "ChampionAt10", class: "fighter", subclass: "champion", level: 10,
+			want: []string{"Improved Critical", "Remarkable Athlete", "Additional Fighting Style"}},
+		{name: "LifeDomainAt1", class: "cleric", subclass: "life domain", level: 1, want: []string{"Bonus Proficiency", "Disciple of Life"}},
+		{name: "EvocationAt6", class: "wizard", subclass: "school of evocation", level: 6,
+			want: []string{"Evocation Savant", "Sculpt Spells", "Potent Cantrip"}},
+		{name: "FiendAt20", class: "warlock", subclass: "the fiend", level: 20,
+			want: []string{"Dark One's Blessing", "Dark One's Own Luck", "Fiendish Resilience", "Hurl Through Hell"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:        tt.name,
+				Class:       tt.class,
+				ClassLevels: map[string]int{tt.class: tt.level},
+				Subclasses:  map[string]string{tt.class: tt.subclass},
+			}
+
+			var got []string
+			for _, feature := range char.SubclassFeatures() {
+				if feature.Level > tt.level || feature.Class != tt.class {
+					t.Errorf("unexpected feature %+v at %s level %d", feature, tt.class, tt.level)
+				}
+				got = append(got, feature.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("features = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTotalLevelDropsSubclass(t *testing.T) {
+	tests := []struct {
+		name         string
+		level        int
+		wantSubclass string
+	}{
+		{name: "StaysAtSubclassLevel", level: 3, wantSubclass: "champion"},
+		{name: "DropsBelowSubclassLevel", level: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := &domain.Character{
+				Name:        tt.name,
+				Class:       "fighter",
+				Level:       5,
+				ClassLevels: map[string]int{"fighter": 5},
+				Subclasses:  map[string]string{"fighter": "champion"},
+			}
+
+			if err := char.SetTotalLevel(tt.level); err != nil {
+				t.Fatalf("SetTotalLevel: %v", err)
+			}
+
+			if got := char.Subclasses["fighter"]; got != tt.wantSubclass {
+				t.Errorf("fighter subclass = %q, expected %q", got, tt.wantSubclass)
+			}
+			if err := char.ValidateSubclasses(); err != nil {
+				t.Errorf("ValidateSubclasses: %v", err)
+			}
+		})
+	}
+}
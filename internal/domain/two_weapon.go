@@ -0,0 +1,63 @@
+package domain
+
+import "fmt"
+
+// DualWieldingACBonus is the AC feats such as Dual Wielder grant while the
+// character wields a separate melee weapon in each hand.
+const DualWieldingACBonus = 1
+
+// CheckTwoWeaponFighting checks a pair of weapons can be wielded together:
+// both have to be Light melee weapons, unless a feat lets the character fight
+// with any one-handed melee weapons.
+func (c *Character) CheckTwoWeaponFighting(mainHand, offHand Weapon) error {
+	for _, w := range []Weapon{mainHand, offHand} {
+		if w.IsRanged() {
+			return fmt.Errorf("two-weapon fighting needs melee weapons, and '%s' is ranged", w.Name)
+		}
+	}
+
+	if mainHand.HasProperty("light") && offHand.HasProperty("light") {
+		return nil
+	}
+
+	if c.hasFeatEffect(func(f FeatData) bool { return f.DualWielder }) {
+		for _, w := range []Weapon{mainHand, offHand} {
+			if w.TwoHanded {
+				return fmt.Errorf("two-weapon fighting needs one-handed melee weapons, and '%s' isn't one", w.Name)
+			}
+		}
+		return nil
+	}
+
+	for _, w := range []Weapon{mainHand, offHand} {
+		if !w.HasProperty("light") {
+			return fmt.Errorf("two-weapon fighting needs Light weapons in both hands, and '%s' isn't Light", w.Name)
+		}
+	}
+	return nil
+}
+
+// EquipShield straps on a shield, which takes up the off hand.
+func (c *Character) EquipShield(shield Shield) error {
+	if c.EquippedWeaponOffHand.Name != "" {
+		return fmt.Errorf("cannot hold shield '%s': off hand is holding '%s'", shield.Name, c.EquippedWeaponOffHand.Name)
+	}
+	if c.EquippedWeaponMainHand.TwoHanded {
+		return fmt.Errorf("cannot hold shield '%s': main hand weapon '%s' is two-handed", shield.Name, c.EquippedWeaponMainHand.Name)
+	}
+
+	c.EquippedShield = shield
+	return nil
+}
+
+// OffHandFree reports whether nothing is held in the off hand, so a
+// versatile weapon can be wielded with both hands.
+func (c *Character) OffHandFree() bool {
+	return c.EquippedWeaponOffHand.Name == "" && c.EquippedShield.Name == ""
+}
+
+// IsDualWielding reports whether the character holds a melee weapon in each hand.
+func (c *Character) IsDualWielding() bool {
+	main, off := c.EquippedWeaponMainHand, c.EquippedWeaponOffHand
+	return main.Name != "" && off.Name != "" && !main.IsRanged() && !off.IsRanged()
+}
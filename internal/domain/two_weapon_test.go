@@ -0,0 +1,91 @@
+package domain_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"testing"
+)
+
+func TestHandedness(t *testing.T) {
+	weapon := func(name, damage, versatile string, properties ...string) domain.Weapon {
+		return domain.Weapon{
+			Equipment:       domain.Equipment{Name: name, Category: "Martial Melee"},
+			Damage:          damage,
+			VersatileDamage: versatile,
+			Properties:      properties,
+			TwoHanded:       len(properties) > 0 && properties[0] == "two-handed",
+		}
+	}
+	longsword := weapon("longsword", "1d8 slashing", "1d10", "versatile")
+	greatsword := weapon("greatsword", "2d6 slashing", "", "two-handed", "heavy")
+	shortsword := weapon("shortsword", "1d6 piercing", "", "finesse", "light")
+	dagger := weapon("dagger", "1d4 piercing", "", "finesse", "light")
+	handCrossbow := weapon("hand crossbow", "1d6 piercing", "", "ammunition", "light", "loading")
+	handCrossbow.Category = "Martial Ranged"
+	shield := domain.Shield{Equipment: domain.Equipment{Name: "shield"}}
+
+	newChar := func(feats ...string) *domain.Character {
+		return &domain.Character{
+			Name:          "Hands",
+			AbilityScores: map[string]domain.Ability{"STR": {Score: 10}, "DEX": {Score: 10}},
+			Feats:         feats,
+		}
+	}
+
+	t.Run("VersatileTwoHanded", func(t *testing.T) {
+		char := newChar()
+		if err := char.EquipWeaponSlot(longsword, "main hand"); err != nil {
+			t.Fatalf("Failed to equip: %v", err)
+		}
+		if got := char.MainHandAttack().DamageDice; got != "1d10" {
+			t.Errorf("damage with a free off hand = %s, expected 1d10", got)
+		}
+
+		if err := char.EquipShield(shield); err != nil {
+			t.Fatalf("Failed to equip shield: %v", err)
+		}
+		if got := char.MainHandAttack().DamageDice; got != "1d8" {
+			t.Errorf("damage with a shield = %s, expected 1d8", got)
+		}
+	})
+
+	t.Run("ShieldTakesOffHand", func(t *testing.T) {
+		char := newChar()
+		if err := char.EquipShield(shield); err != nil {
+			t.Fatalf("Failed to equip shield: %v", err)
+		}
+		if err := char.EquipWeaponSlot(dagger, "off hand"); err == nil {
+			t.Errorf("expected an error equipping an off-hand weapon with a shield")
+		}
+		if err := char.EquipWeaponSlot(greatsword, "main hand"); err == nil {
+			t.Errorf("expected an error wielding a two-handed weapon with a shield")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		mainHand domain.Weapon
+		offHand  domain.Weapon
+		feats    []string
+		wantErr  bool
+	}{
+		{name: "BothLight", mainHand: shortsword, offHand: dagger},
+		{name: "MainNotLight", mainHand: longsword, offHand: dagger, wantErr: true},
+		{name: "DualWielder", mainHand: longsword, offHand: dagger, feats: []string{"dual wielder"}},
+		{name: "LightRanged", mainHand: shortsword, offHand: handCrossbow, wantErr: true},
+		{name: "DualWielderRanged", mainHand: longsword, offHand: handCrossbow, feats: []string{"dual wielder"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char := newChar(tt.feats...)
+			if err := char.EquipWeaponSlot(tt.mainHand, "main hand"); err != nil {
+				t.Fatalf("Failed to equip main hand: %v", err)
+			}
+
+			err := char.EquipWeaponSlot(tt.offHand, "off hand")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("off hand error = %v, expected error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"strconv"
 	"strings"
@@ -10,24 +12,42 @@ import (
 	"dnd-char-generator/internal/domain"
 )
 
-func LoadData(equipmentPath string, spellsPath string) (
+func LoadData(equipmentPath string, spellsPath string, spellCompendiumPath string, classFeaturesPath string) (
 	map[int][]domain.Spell,
 	map[string]domain.Weapon,
 	map[string]domain.Armor,
 	map[string]domain.Shield,
+	map[string]domain.Equipment,
+	map[string][]domain.ClassFeature,
 	error) {
 
 	allSpells, err := LoadSpellData(spellsPath)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
-	allWeapons, allArmors, allShields, err := LoadEquipmentData(equipmentPath)
+	// Without a compendium, spell details are fetched from the API instead
+	compendium, err := LoadSpellCompendium(spellCompendiumPath)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Printf("Warning: Spell compendium '%s' not found, spell details will come from the API\n", spellCompendiumPath)
+	case err != nil:
+		return nil, nil, nil, nil, nil, nil, err
+	default:
+		MergeSpellCompendium(allSpells, compendium)
+	}
+
+	allWeapons, allArmors, allShields, allGear, err := LoadEquipmentData(equipmentPath)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, err
+	}
+
+	classFeatures, err := LoadClassFeatureData(classFeaturesPath)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, nil, err
 	}
 
-	return allSpells, allWeapons, allArmors, allShields, nil
+	return allSpells, allWeapons, allArmors, allShields, allGear, classFeatures, nil
 }
 
 func LoadSpellData(filePath string) (map[int][]domain.Spell, error) {
@@ -78,16 +98,93 @@ func LoadSpellData(filePath string) (map[int][]domain.Spell, error) {
 			Class: classNames,
 		}
 
+		// The columns after class are optional; without them the API
+		// enrichment fills them in
+		if len(record) > 4 {
+			concentration, err := strconv.ParseBool(strings.TrimSpace(record[3]))
+			if err != nil {
+				fmt.Printf("Warning: Spell '%s' has invalid concentration '%s', assuming false\n", name, record[3])
+			}
+
+			spell.Concentration = concentration
+			spell.Duration = strings.TrimSpace(record[4])
+		}
+
+		if len(record) > 7 {
+			ritual, err := strconv.ParseBool(strings.TrimSpace(record[5]))
+			if err != nil {
+				fmt.Printf("Warning: Spell '%s' has invalid ritual '%s', assuming false\n", name, record[5])
+			}
+
+			spell.Ritual = ritual
+			spell.CastingTime = strings.TrimSpace(record[6])
+
+			for _, component := range strings.Split(record[7], ",") {
+				if component = strings.TrimSpace(component); component != "" {
+					spell.Components = append(spell.Components, strings.ToUpper(component))
+				}
+			}
+		}
+
 		spellsByLevel[level] = append(spellsByLevel[level], spell)
 	}
 
 	return spellsByLevel, nil
 }
 
-func LoadEquipmentData(filePath string) (map[string]domain.Weapon, map[string]domain.Armor, map[string]domain.Shield, error) {
+func LoadClassFeatureData(filePath string) (map[string][]domain.ClassFeature, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open class features file: %w", err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			fmt.Printf("could not close file: %v", err)
+		}
+	}(file)
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading class features CSV: %w", err)
+	}
+
+	featuresByClass := make(map[string][]domain.ClassFeature)
+
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+
+		className := strings.ToLower(strings.TrimSpace(record[0]))
+		levelStr := strings.TrimSpace(record[1])
+		name := strings.TrimSpace(record[2])
+
+		level, err := strconv.Atoi(levelStr)
+		if err != nil {
+			fmt.Printf("Warning: Skipping class feature '%s' with invalid level '%s'\n", name, levelStr)
+			continue
+		}
+
+		feature := domain.ClassFeature{
+			Name:     name,
+			Class:    className,
+			Level:    level,
+			Uses:     strings.ToUpper(strings.TrimSpace(record[3])),
+			Recharge: domain.RestType(strings.ToLower(strings.TrimSpace(record[4]))),
+		}
+
+		featuresByClass[className] = append(featuresByClass[className], feature)
+	}
+
+	return featuresByClass, nil
+}
+
+func LoadEquipmentData(filePath string) (map[string]domain.Weapon, map[string]domain.Armor, map[string]domain.Shield, map[string]domain.Equipment, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("could not open equipment file: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("could not open equipment file: %w", err)
 	}
 	defer func(file *os.File) {
 		err := file.Close()
@@ -97,89 +194,138 @@ func LoadEquipmentData(filePath string) (map[string]domain.Weapon, map[string]do
 	}(file)
 
 	reader := csv.NewReader(file)
+	// Rows only carry the columns relevant to their type
+	reader.FieldsPerRecord = -1
 	records, err := reader.ReadAll()
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error reading equipment CSV: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("error reading equipment CSV: %w", err)
 	}
 
 	allWeapons := make(map[string]domain.Weapon)
 	allArmors := make(map[string]domain.Armor)
 	allShields := make(map[string]domain.Shield)
+	allGear := make(map[string]domain.Equipment)
 
 	for i, record := range records {
 		if i == 0 {
 			continue
 		}
 
-		name := record[0]
-		itemType := record[1]
+		column := func(index int) string {
+			if index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
 
+		name := column(0)
+		itemType := column(1)
 		key := strings.ToLower(name)
 
 		baseEq := domain.Equipment{
-			Name: key,
-			Type: itemType,
+			Name:     key,
+			Type:     itemType,
+			Category: column(2),
+		}
+
+		if cost := column(3); cost != "" {
+			baseEq.Cost, err = domain.ParseCost(cost)
+			if err != nil {
+				fmt.Printf("Warning: Item '%s' has %v, assuming it is free\n", name, err)
+			}
+		}
+
+		if weight := column(4); weight != "" {
+			baseEq.Weight, err = strconv.ParseFloat(weight, 64)
+			if err != nil {
+				fmt.Printf("Warning: Item '%s' has invalid weight '%s', assuming 0\n", name, weight)
+			}
+		}
+
+		if quantity := column(12); quantity != "" {
+			baseEq.Quantity, err = strconv.Atoi(quantity)
+			if err != nil {
+				fmt.Printf("Warning: Item '%s' has invalid quantity '%s', assuming 1\n", name, quantity)
+			}
 		}
 
 		switch itemType {
 		case "Weapon":
-			allWeapons[key] = domain.Weapon{
-				Equipment: baseEq,
-			}
+			allWeapons[key] = parseWeapon(baseEq, column(5), column(6), column(7))
 
 		case "Armor":
-			if strings.EqualFold(name, "Shield") {
-				allShields[key] = domain.Shield{Equipment: baseEq}
+			armorClass, err := strconv.Atoi(column(8))
+			if err != nil {
+				fmt.Printf("Warning: Skipping armor '%s' with invalid armor class '%s'\n", name, column(8))
+				continue
+			}
+
+			if strings.EqualFold(baseEq.Category, "Shield") || strings.EqualFold(name, "Shield") {
+				allShields[key] = domain.Shield{Equipment: baseEq, ACBonus: armorClass}
 				continue
 			}
 
 			armor := domain.Armor{
 				Equipment: baseEq,
+				AC:        armorClass,
+				DexBonus:  strings.ToLower(column(9)),
 			}
 
-			switch name {
-			// Light armor
-			case "Padded Armor":
-				armor.AC, armor.DexBonus = 11, "full"
-			case "Leather Armor":
-				armor.AC, armor.DexBonus = 11, "full"
-			case "Studded Leather Armor":
-				armor.AC, armor.DexBonus = 12, "full"
-
-			// Medium armor
-			case "Hide Armor":
-				armor.AC, armor.DexBonus = 12, "limited"
-			case "Chain Shirt":
-				armor.AC, armor.DexBonus = 13, "limited"
-			case "Scale Mail":
-				armor.AC, armor.DexBonus = 14, "limited"
-			case "Breastplate":
-				armor.AC, armor.DexBonus = 14, "limited"
-			case "Half Plate":
-				armor.AC, armor.DexBonus = 15, "limited"
-
-			// Heavy armor
-			case "Ring Mail":
-				armor.AC, armor.DexBonus = 14, "none"
-			case "Chain Mail":
-				armor.AC, armor.DexBonus = 16, "none"
-			case "Splint Armor":
-				armor.AC, armor.DexBonus = 17, "none"
-			case "Plate Armor":
-				armor.AC, armor.DexBonus = 18, "none"
-
-			default:
-				continue
+			if strength := column(10); strength != "" {
+				armor.StrengthRequirement, err = strconv.Atoi(strength)
+				if err != nil {
+					fmt.Printf("Warning: Armor '%s' has invalid strength requirement '%s', assuming none\n", name, strength)
+				}
 			}
 
+			armor.StealthDisadvantage, _ = strconv.ParseBool(column(11))
+
 			allArmors[key] = armor
 
 		case "Shield":
-			allShields[key] = domain.Shield{
-				Equipment: baseEq,
-			}
+			allShields[key] = domain.Shield{Equipment: baseEq, ACBonus: 2}
+
+		default:
+			allGear[key] = baseEq
 		}
 	}
 
-	return allWeapons, allArmors, allShields, nil
+	return allWeapons, allArmors, allShields, allGear, nil
+}
+
+// parseWeapon builds a weapon from its damage ("1d8 slashing"), comma separated
+// properties ("Thrown, Versatile (1d8)") and normal/long range ("20/60").
+func parseWeapon(baseEq domain.Equipment, damage, properties, weaponRange string) domain.Weapon {
+	weapon := domain.Weapon{
+		Equipment: baseEq,
+		Damage:    damage,
+	}
+
+	for _, property := range strings.Split(properties, ",") {
+		property = strings.ToLower(strings.TrimSpace(property))
+		if property == "" {
+			continue
+		}
+
+		// Versatile carries its two-handed damage die, e.g. "versatile (1d10)"
+		if strings.HasPrefix(property, "versatile") {
+			weapon.VersatileDamage = strings.Trim(strings.TrimPrefix(property, "versatile"), " ()")
+			property = "versatile"
+		}
+
+		weapon.Properties = append(weapon.Properties, property)
+	}
+
+	weapon.TwoHanded = weapon.HasProperty("two-handed")
+
+	switch {
+	case weaponRange != "":
+		weapon.Equipment.Range = weaponRange + " ft."
+	case weapon.HasProperty("reach"):
+		weapon.Equipment.Range = "10 ft."
+	default:
+		weapon.Equipment.Range = "5 ft."
+	}
+
+	return weapon
 }
@@ -0,0 +1,84 @@
+package infrastructure_test
+
+import (
+	"dnd-char-generator/internal/infrastructure"
+	"slices"
+	"testing"
+)
+
+func TestLoadEquipmentData(t *testing.T) {
+	weapons, armors, shields, gear, err := infrastructure.LoadEquipmentData("testdata/equipment.csv")
+	if err != nil {
+		t.Fatalf("Failed to load equipment: %v", err)
+	}
+
+	t.Run("Weapons", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			wantDamage     string
+			wantVersatile  string
+			wantProperties []string
+			wantTwoHanded  bool
+			wantRange      string
+			wantCost       int
+		}{
+			{name: "crossbow, light", wantDamage: "1d8 piercing", wantProperties: []string{"ammunition", "loading", "two-handed"},
+				wantTwoHanded: true, wantRange: "80/320 ft.", wantCost: 2500},
+			{name: "longsword", wantDamage: "1d8 slashing", wantVersatile: "1d10", wantProperties: []string{"versatile"},
+				wantRange: "5 ft.", wantCost: 1500},
+			{name: "glaive", wantDamage: "1d10 slashing", wantProperties: []string{"heavy", "reach", "two-handed"},
+				wantTwoHanded: true, wantRange: "10 ft.", wantCost: 2000},
+		}
+
+		for _, tt := range tests {
+			weapon, ok := weapons[tt.name]
+			if !ok {
+				t.Errorf("weapon %q not loaded", tt.name)
+				continue
+			}
+
+			if weapon.Damage != tt.wantDamage || weapon.VersatileDamage != tt.wantVersatile {
+				t.Errorf("%s damage %q (versatile %q), expected %q (versatile %q)", tt.name, weapon.Damage, weapon.VersatileDamage, tt.wantDamage, tt.wantVersatile)
+			}
+			if !slices.Equal(weapon.Properties, tt.wantProperties) {
+				t.Errorf("%s properties = %q, expected %q", tt.name, weapon.Properties, tt.wantProperties)
+			}
+			if weapon.TwoHanded != tt.wantTwoHanded || weapon.Range != tt.wantRange {
+				t.Errorf("%s two-handed %v, range %q, expected %v, %q", tt.name, weapon.TwoHanded, weapon.Range, tt.wantTwoHanded, tt.wantRange)
+			}
+			if weapon.Cost != tt.wantCost {
+				t.Errorf("%s costs %d cp, expected %d", tt.name, weapon.Cost, tt.wantCost)
+			}
+		}
+	})
+
+	t.Run("Armor", func(t *testing.T) {
+		chain := armors["chain mail"]
+		if chain.AC != 16 || chain.StrengthRequirement != 13 || !chain.StealthDisadvantage || chain.ArmorCategory() != "heavy" {
+			t.Errorf("chain mail = %+v", chain)
+		}
+		if studded := armors["studded leather"]; studded.AC != 12 || studded.DexBonus != "full" || studded.StealthDisadvantage {
+			t.Errorf("studded leather = %+v", studded)
+		}
+		if _, ok := armors["broken plate"]; ok {
+			t.Errorf("expected armor without an armor class to be skipped")
+		}
+
+		if _, ok := armors["shield"]; ok {
+			t.Errorf("expected the shield to load as a shield, not armor")
+		}
+		if shield := shields["shield"]; shield.ACBonus != 2 || shield.Weight != 6 {
+			t.Errorf("shield = %+v", shield)
+		}
+	})
+
+	t.Run("BundleQuantity", func(t *testing.T) {
+		arrow := gear["arrow"]
+		if arrow.Quantity != 20 || arrow.Cost != 100 || arrow.Weight != 1 {
+			t.Errorf("arrows = %+v, expected a bundle of 20 for 1 gp weighing 1 lb", arrow)
+		}
+		if rope, ok := gear["rope, hempen (50 feet)"]; !ok || rope.Quantity != 0 {
+			t.Errorf("rope = %+v, expected a single item", rope)
+		}
+	})
+}
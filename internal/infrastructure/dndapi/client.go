@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"time"
+	"unicode"
 
 	"dnd-char-generator/internal/domain"
 )
@@ -77,15 +78,33 @@ func (c *Client) getResource(ctx context.Context, endpoint string, target interf
 	return nil
 }
 
+// slug turns a name into its API index, e.g. "Crossbow, light" into
+// "crossbow-light" and "Hunter's Mark" into "hunters-mark".
+func slug(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "'", "")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
+
 func (c *Client) EnrichSpell(ctx context.Context, spell *domain.Spell) {
 	if spell.Name == "" {
 		return
 	}
 
-	spellNameSlug := strings.ToLower(strings.ReplaceAll(spell.Name, " ", "-"))
+	spellNameSlug := slug(spell.Name)
 
 	var apiSpell struct {
-		Range string `json:"range"`
+		Range         string   `json:"range"`
+		Concentration bool     `json:"concentration"`
+		Duration      string   `json:"duration"`
+		Ritual        bool     `json:"ritual"`
+		CastingTime   string   `json:"casting_time"`
+		Components    []string `json:"components"`
+		Material      string   `json:"material"`
+		Desc          []string `json:"desc"`
+		HigherLevel   []string `json:"higher_level"`
 
 		School struct {
 			Name string `json:"name"`
@@ -96,11 +115,33 @@ func (c *Client) EnrichSpell(ctx context.Context, spell *domain.Spell) {
 	if err := c.getResource(ctx, endpoint, &apiSpell); err == nil {
 		spell.Range = apiSpell.Range
 		spell.School = apiSpell.School.Name
+		spell.Concentration = spell.Concentration || apiSpell.Concentration
+
+		spell.Ritual = spell.Ritual || apiSpell.Ritual
+
+		if spell.Duration == "" {
+			spell.Duration = apiSpell.Duration
+		}
+		if spell.CastingTime == "" {
+			spell.CastingTime = apiSpell.CastingTime
+		}
+		if len(spell.Components) == 0 {
+			spell.Components = apiSpell.Components
+		}
+		if spell.Material == "" {
+			spell.Material = apiSpell.Material
+		}
+		if spell.Description == "" {
+			spell.Description = strings.Join(apiSpell.Desc, "\n")
+		}
+		if spell.HigherLevel == "" {
+			spell.HigherLevel = strings.Join(apiSpell.HigherLevel, "\n")
+		}
 	}
 }
 
 func (c *Client) EnrichWeapon(ctx context.Context, weapon *domain.Weapon) {
-	weaponNameSlug := strings.ToLower(strings.ReplaceAll(weapon.Name, " ", "-"))
+	weaponNameSlug := slug(weapon.Name)
 
 	var apiWeapon struct {
 		CategoryRange string `json:"category_range"`
@@ -123,7 +164,7 @@ func (c *Client) EnrichWeapon(ctx context.Context, weapon *domain.Weapon) {
 
 	endpoint := "equipment/" + weaponNameSlug
 	if err := c.getResource(ctx, endpoint, &apiWeapon); err == nil {
-		if apiWeapon.Damage.DamageDice != "" {
+		if weapon.Damage == "" && apiWeapon.Damage.DamageDice != "" {
 			primaryDamage := apiWeapon.Damage
 
 			if primaryDamage.DamageType.Name != "" {
@@ -136,34 +177,42 @@ func (c *Client) EnrichWeapon(ctx context.Context, weapon *domain.Weapon) {
 			}
 		}
 
-		weapon.Equipment.Category = apiWeapon.CategoryRange
+		if weapon.Equipment.Category == "" {
+			weapon.Equipment.Category = apiWeapon.CategoryRange
+		}
 
-		if apiWeapon.Range.Normal > 5 {
-			weapon.Equipment.Range = fmt.Sprintf("%d ft. (Ranged)", apiWeapon.Range.Normal)
-		} else {
-			weapon.Equipment.Range = "5 ft. (Melee)"
+		if weapon.Equipment.Range == "" {
+			if apiWeapon.Range.Normal > 5 {
+				weapon.Equipment.Range = fmt.Sprintf("%d ft. (Ranged)", apiWeapon.Range.Normal)
+			} else {
+				weapon.Equipment.Range = "5 ft. (Melee)"
+			}
 		}
 
-		isTwoHanded := false
-		for _, prop := range apiWeapon.Properties {
-			if prop.Name == "Two-Handed" {
-				isTwoHanded = true
-				break
+		if len(weapon.Properties) == 0 {
+			isTwoHanded := false
+			var properties []string
+			for _, prop := range apiWeapon.Properties {
+				if prop.Name == "Two-Handed" {
+					isTwoHanded = true
+				}
+				properties = append(properties, strings.ToLower(prop.Name))
 			}
+			weapon.TwoHanded = isTwoHanded
+			weapon.Properties = properties
 		}
-		weapon.TwoHanded = isTwoHanded
 	}
 }
 
 func (c *Client) EnrichArmor(ctx context.Context, armor *domain.Armor) {
-	armorNameSlug := strings.ToLower(strings.ReplaceAll(armor.Name, " ", "-"))
+	armorNameSlug := slug(armor.Name)
 
 	var apiArmor struct {
 		ArmorCategory string `json:"armor_category"`
 	}
 
 	endpoint := "equipment/" + armorNameSlug
-	if err := c.getResource(ctx, endpoint, &apiArmor); err == nil {
+	if err := c.getResource(ctx, endpoint, &apiArmor); err == nil && armor.Equipment.Category == "" {
 		armor.Equipment.Category = apiArmor.ArmorCategory
 	}
 }
@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+type magicItemModifier struct {
+	Target    string `json:"target"`
+	Ability   string `json:"ability"`
+	Value     int    `json:"value"`
+	Set       bool   `json:"set"`
+	Unarmored bool   `json:"unarmored"`
+}
+
+type magicItemEntry struct {
+	Name       string              `json:"name"`
+	Type       string              `json:"type"`
+	Rarity     string              `json:"rarity"`
+	Attunement bool                `json:"attunement"`
+	Weight     float64             `json:"weight"`
+	Charges    int                 `json:"charges"`
+	Recharge   string              `json:"recharge"`
+	Desc       string              `json:"desc"`
+	Modifiers  []magicItemModifier `json:"modifiers"`
+}
+
+// LoadMagicItems reads the magic item catalogue, keyed by lowercase item name.
+// Magic weapons, armor and shields are templates that still need a base item.
+func LoadMagicItems(filePath string) (map[string]domain.MagicItem, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open magic items file: %w", err)
+	}
+
+	var entries []magicItemEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error reading magic items JSON: %w", err)
+	}
+
+	items := make(map[string]domain.MagicItem, len(entries))
+
+	for _, entry := range entries {
+		name := strings.ToLower(strings.TrimSpace(entry.Name))
+		if name == "" {
+			fmt.Println("Warning: Skipping magic item without a name")
+			continue
+		}
+
+		item := domain.MagicItem{
+			Name:               name,
+			Type:               strings.ToLower(strings.TrimSpace(entry.Type)),
+			Rarity:             domain.Rarity(strings.ToLower(strings.TrimSpace(entry.Rarity))),
+			RequiresAttunement: entry.Attunement,
+			Weight:             entry.Weight,
+			Description:        entry.Desc,
+			MaxCharges:         entry.Charges,
+			Recharge:           entry.Recharge,
+		}
+
+		for _, mod := range entry.Modifiers {
+			item.Modifiers = append(item.Modifiers, domain.ItemModifier{
+				Target:    domain.Stat(strings.ToLower(strings.TrimSpace(mod.Target))),
+				Ability:   strings.ToUpper(strings.TrimSpace(mod.Ability)),
+				Value:     mod.Value,
+				Set:       mod.Set,
+				Unarmored: mod.Unarmored,
+			})
+		}
+
+		items[name] = item
+	}
+
+	return items, nil
+}
@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// LoadRules reads the optional rules from environment variables, e.g.
+// DND_EQUIP_FROM_INVENTORY=true, DND_VARIANT_ENCUMBRANCE=true or
+// DND_REFUSE_NONPROFICIENT=true.
+func LoadRules() domain.Rules {
+	return domain.Rules{
+		EquipFromInventory: boolSetting("DND_EQUIP_FROM_INVENTORY"),
+		VariantEncumbrance: boolSetting("DND_VARIANT_ENCUMBRANCE"),
+
+		RefuseNonProficientEquipment: boolSetting("DND_REFUSE_NONPROFICIENT"),
+	}
+}
+
+func boolSetting(name string) bool {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		fmt.Printf("Warning: Ignoring %s='%s', expected true or false\n", name, value)
+		return false
+	}
+	return enabled
+}
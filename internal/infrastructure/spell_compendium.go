@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"dnd-char-generator/internal/domain"
+)
+
+// compendiumSpell is a spell entry in the same shape as the 5e SRD database,
+// so API dumps can be used as a compendium file directly.
+type compendiumSpell struct {
+	Name          string   `json:"name"`
+	Level         int      `json:"level"`
+	CastingTime   string   `json:"casting_time"`
+	Range         string   `json:"range"`
+	Components    []string `json:"components"`
+	Material      string   `json:"material"`
+	Duration      string   `json:"duration"`
+	Concentration bool     `json:"concentration"`
+	Ritual        bool     `json:"ritual"`
+	Desc          []string `json:"desc"`
+	HigherLevel   []string `json:"higher_level"`
+
+	School struct {
+		Name string `json:"name"`
+	} `json:"school"`
+
+	Classes []struct {
+		Name string `json:"name"`
+	} `json:"classes"`
+}
+
+// LoadSpellCompendium reads a JSON spell compendium, keyed by lowercase spell name.
+func LoadSpellCompendium(filePath string) (map[string]domain.Spell, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open spell compendium: %w", err)
+	}
+
+	var entries []compendiumSpell
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error reading spell compendium JSON: %w", err)
+	}
+
+	compendium := make(map[string]domain.Spell, len(entries))
+
+	for _, entry := range entries {
+		name := strings.ToLower(strings.TrimSpace(entry.Name))
+		if name == "" {
+			fmt.Println("Warning: Skipping spell compendium entry without a name")
+			continue
+		}
+
+		spell := domain.Spell{
+			Name:          name,
+			Level:         entry.Level,
+			School:        entry.School.Name,
+			Range:         entry.Range,
+			Concentration: entry.Concentration,
+			Duration:      entry.Duration,
+			Ritual:        entry.Ritual,
+			CastingTime:   entry.CastingTime,
+			Material:      entry.Material,
+			Description:   strings.Join(entry.Desc, "\n"),
+			HigherLevel:   strings.Join(entry.HigherLevel, "\n"),
+		}
+
+		for _, class := range entry.Classes {
+			spell.Class = append(spell.Class, class.Name)
+		}
+		for _, component := range entry.Components {
+			spell.Components = append(spell.Components, strings.ToUpper(strings.TrimSpace(component)))
+		}
+
+		compendium[name] = spell
+	}
+
+	return compendium, nil
+}
+
+// MergeSpellCompendium completes the spells loaded from the spell list with
+// the compendium's details. Level and classes stay as the list has them;
+// compendium spells missing from the list are added.
+func MergeSpellCompendium(spellsByLevel map[int][]domain.Spell, compendium map[string]domain.Spell) {
+	listed := make(map[string]bool)
+
+	for level, spells := range spellsByLevel {
+		for i, spell := range spells {
+			details, ok := compendium[spell.Name]
+			if !ok {
+				continue
+			}
+
+			spell.School = details.School
+			spell.Range = details.Range
+			spell.Material = details.Material
+			spell.Description = details.Description
+			spell.HigherLevel = details.HigherLevel
+			spell.Concentration = spell.Concentration || details.Concentration
+			spell.Ritual = spell.Ritual || details.Ritual
+
+			if spell.Duration == "" {
+				spell.Duration = details.Duration
+			}
+			if spell.CastingTime == "" {
+				spell.CastingTime = details.CastingTime
+			}
+			if len(spell.Components) == 0 {
+				spell.Components = details.Components
+			}
+
+			spellsByLevel[level][i] = spell
+			listed[spell.Name] = true
+		}
+	}
+
+	var unlisted []string
+	for name := range compendium {
+		if !listed[name] {
+			unlisted = append(unlisted, name)
+		}
+	}
+	sort.Strings(unlisted)
+
+	for _, name := range unlisted {
+		spell := compendium[name]
+		spellsByLevel[spell.Level] = append(spellsByLevel[spell.Level], spell)
+	}
+}
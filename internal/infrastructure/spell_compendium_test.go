@@ -0,0 +1,87 @@
+package infrastructure_test
+
+import (
+	"dnd-char-generator/internal/domain"
+	"dnd-char-generator/internal/infrastructure"
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+)
+
+func TestLoadSpellCompendium(t *testing.T) {
+	compendium, err := infrastructure.LoadSpellCompendium("testdata/spell-compendium.json")
+	if err != nil {
+		t.Fatalf("Failed to load compendium: %v", err)
+	}
+
+	if len(compendium) != 3 {
+		t.Errorf("loaded %d spells, expected 3 without the nameless entry", len(compendium))
+	}
+
+	detect, ok := compendium["detect magic"]
+	if !ok {
+		t.Fatalf("expected spells keyed by lowercase name, got %v", compendium)
+	}
+	if detect.School != "Divination" || !detect.Ritual || !detect.Concentration {
+		t.Errorf("detect magic = %+v", detect)
+	}
+	if want := "For the duration, you sense the presence of magic within 30 feet of you.\nThe spell can penetrate most barriers."; detect.Description != want {
+		t.Errorf("description = %q, expected paragraphs joined by newlines", detect.Description)
+	}
+	if got := compendium["shield"].Components; !slices.Equal(got, []string{"V", "S"}) {
+		t.Errorf("components = %q, expected [V S]", got)
+	}
+
+	if _, err := infrastructure.LoadSpellCompendium("testdata/missing.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing compendium error = %v, expected it to wrap fs.ErrNotExist", err)
+	}
+}
+
+func TestMergeSpellCompendium(t *testing.T) {
+	spells, err := infrastructure.LoadSpellData("testdata/spells.csv")
+	if err != nil {
+		t.Fatalf("Failed to load spells: %v", err)
+	}
+	compendium, err := infrastructure.LoadSpellCompendium("testdata/spell-compendium.json")
+	if err != nil {
+		t.Fatalf("Failed to load compendium: %v", err)
+	}
+
+	infrastructure.MergeSpellCompendium(spells, compendium)
+
+	find := func(name string) (domain.Spell, bool) {
+		for _, atLevel := range spells {
+			for _, spell := range atLevel {
+				if spell.Name == name {
+					return spell, true
+				}
+			}
+		}
+		return domain.Spell{}, false
+	}
+
+	detect, ok := find("detect magic")
+	if !ok {
+		t.Fatalf("detect magic missing after merge")
+	}
+	// The spell list wins on level, classes and the fields it has
+	if detect.Level != 1 || len(detect.Class) != 3 {
+		t.Errorf("level %d, classes %v, expected the list's level 1 and 3 classes", detect.Level, detect.Class)
+	}
+	if detect.Duration != "Up to 10 minutes" || detect.CastingTime != "1 action" {
+		t.Errorf("duration %q, casting time %q, expected the list's values", detect.Duration, detect.CastingTime)
+	}
+	// The compendium fills in the details the list doesn't have
+	if detect.School != "Divination" || detect.Range != "Self" || !detect.IsComplete() {
+		t.Errorf("school %q, range %q, expected the compendium's details", detect.School, detect.Range)
+	}
+
+	if bolt, _ := find("fire bolt"); bolt.IsComplete() {
+		t.Errorf("fire bolt isn't in the compendium and should be left for the API")
+	}
+
+	if len(spells[9]) != 1 || spells[9][0].Name != "wish" {
+		t.Errorf("level 9 spells = %v, expected wish added from the compendium", spells[9])
+	}
+}
@@ -2,13 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
+	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/dice"
 	"dnd-char-generator/internal/domain"
 	"dnd-char-generator/internal/infrastructure"
 	"dnd-char-generator/internal/infrastructure/dndapi"
@@ -17,20 +23,51 @@ import (
 
 func usage() {
 	fmt.Printf(`Usage:
-  %s create -name NAME -race RACE -class CLASS -str N -dex N -con N -int N -wis N -cha N
-  %s view -name CHARACTER_NAME
-  %s list
-  %s delete -name CHARACTER_NAME
-  %s equip -name CHARACTER_NAME -weapon WEAPON_NAME -slot SLOT
-  %s equip -name CHARACTER_NAME -armor ARMOR_NAME
-  %s equip -name CHARACTER_NAME -shield SHIELD_NAME
-  %s learn-spell -name CHARACTER_NAME -spell SPELL_NAME
-  %s prepare-spell -name CHARACTER_NAME -spell SPELL_NAME 
-`, os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0], os.Args[0])
+  %[1]s create -name NAME -race RACE -class CLASS [-subclass SUBCLASS] [-method standard|point-buy|rolled] [-seed N] -str N -dex N -con N -int N -wis N -cha N
+  %[1]s create -name NAME -race RACE -class CLASS -roll [-seed N]
+  %[1]s create ... [-starting-gear equipment|wealth [-roll-wealth]]
+  %[1]s view -name CHARACTER_NAME
+  %[1]s explain -name CHARACTER_NAME -stat ac|initiative|"passive perception"|"max hp"|speed|"spell attack"|"spell save dc"|"DEX save"|SKILL|"main hand"|"off hand"
+  %[1]s list
+  %[1]s delete -name CHARACTER_NAME
+  %[1]s equip -name CHARACTER_NAME -weapon WEAPON_NAME -slot SLOT
+  %[1]s equip -name CHARACTER_NAME -armor ARMOR_NAME
+  %[1]s equip -name CHARACTER_NAME -shield SHIELD_NAME
+  %[1]s unequip -name CHARACTER_NAME -slot "main hand"|"off hand"|armor|shield
+  %[1]s add-item -name CHARACTER_NAME -item ITEM_NAME [-count N] [-container CONTAINER]
+  %[1]s remove-item -name CHARACTER_NAME -item ITEM_NAME [-count N] [-container CONTAINER]
+  %[1]s buy -name CHARACTER_NAME -item ITEM_NAME [-count N] [-container CONTAINER]
+  %[1]s sell -name CHARACTER_NAME -item ITEM_NAME [-count N] [-container CONTAINER]
+  %[1]s coins -name CHARACTER_NAME [-add "15 gp" | -spend "3 sp"]
+  %[1]s add-magic-item -name CHARACTER_NAME -item MAGIC_ITEM [-base ITEM_NAME]
+  %[1]s attune -name CHARACTER_NAME -item MAGIC_ITEM [-end]
+  %[1]s use-charge -name CHARACTER_NAME -item MAGIC_ITEM [-count N]
+  %[1]s transfer-item -from CHARACTER_NAME -to CHARACTER_NAME -item ITEM_NAME [-count N] [-container CONTAINER]
+  %[1]s learn-spell -name CHARACTER_NAME -spell SPELL_NAME [-class CLASS]
+  %[1]s prepare-spell -name CHARACTER_NAME -spell SPELL_NAME [-class CLASS]
+  %[1]s forget-spell -name CHARACTER_NAME -spell SPELL_NAME
+  %[1]s swap-spell -name CHARACTER_NAME -old SPELL_NAME -new SPELL_NAME [-class CLASS]
+  %[1]s cast -name CHARACTER_NAME -spell SPELL_NAME [-slot N | -ritual]
+  %[1]s spellbook -name CHARACTER_NAME -spell SPELL_NAME [-copy-from scroll|spellbook]
+  %[1]s update-level -name CHARACTER_NAME -level N [-subclass SUBCLASS]
+  %[1]s roll -expr EXPRESSION [-seed N]
+  %[1]s attack -name CHARACTER_NAME -hand main|off
+  %[1]s level-up -name CHARACTER_NAME -class CLASS [-subclass SUBCLASS] [-asi STR+2 | -feat FEAT [-feat-ability ABILITY]]
+  %[1]s damage -name CHARACTER_NAME -amount N [-crit] [-roll-concentration]
+  %[1]s heal -name CHARACTER_NAME -amount N
+  %[1]s temp-hp -name CHARACTER_NAME -amount N
+  %[1]s death-save -name CHARACTER_NAME
+  %[1]s short-rest -name CHARACTER_NAME [-hit-dice N]
+  %[1]s long-rest -name CHARACTER_NAME
+  %[1]s apply-condition -name CHARACTER_NAME -condition CONDITION [-source SOURCE] [-rounds N] [-levels N]
+  %[1]s remove-condition -name CHARACTER_NAME -condition CONDITION
+  %[1]s pass-time -name CHARACTER_NAME -rounds N
+`, os.Args[0])
 }
 
 func initApp() (*application.CharacterService, error) {
-	allSpells, allWeapons, allArmors, allShields, err := infrastructure.LoadData("5e-SRD-Equipment.csv", "5e-SRD-Spells.csv")
+	allSpells, allWeapons, allArmors, allShields, allGear, classFeatures, err := infrastructure.LoadData(
+		"5e-SRD-Equipment.csv", "5e-SRD-Spells.csv", "5e-SRD-Spell-Compendium.json", "5e-SRD-Class-Features.csv")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load static SRD data: %w", err)
 	}
@@ -38,7 +75,18 @@ func initApp() (*application.CharacterService, error) {
 	repo := persistence.NewFileRepository("characters.json")
 	apiClient := dndapi.NewClient()
 
-	service := application.NewCharacterService(repo, apiClient, allSpells, allWeapons, allArmors, allShields)
+	service := application.NewCharacterService(repo, apiClient, allSpells, allWeapons, allArmors, allShields, allGear, classFeatures)
+	service.Rules = infrastructure.LoadRules()
+
+	magicItems, err := infrastructure.LoadMagicItems("5e-SRD-Magic-Items.json")
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Println("Warning: Magic items file '5e-SRD-Magic-Items.json' not found, no magic items available")
+	case err != nil:
+		return nil, fmt.Errorf("failed to load magic items: %w", err)
+	default:
+		service.MagicItems = magicItems
+	}
 
 	return service, nil
 }
@@ -63,18 +111,72 @@ func main() {
 		handleCreate(ctx, service)
 	case "view":
 		handleView(ctx, service)
+	case "explain":
+		handleExplain(ctx, service)
 	case "list":
 		handleList(ctx, service)
 	case "update-level":
 		handleUpdateLevel(ctx, service)
+	case "level-up":
+		handleLevelUp(ctx, service)
 	case "equip":
 		handleEquip(ctx, service)
+	case "unequip":
+		handleUnequip(ctx, service)
+	case "add-item":
+		handleAddItem(ctx, service)
+	case "remove-item":
+		handleRemoveItem(ctx, service)
+	case "transfer-item":
+		handleTransferItem(ctx, service)
+	case "buy":
+		handleBuy(ctx, service)
+	case "sell":
+		handleSell(ctx, service)
+	case "coins":
+		handleCoins(ctx, service)
+	case "add-magic-item":
+		handleAddMagicItem(ctx, service)
+	case "attune":
+		handleAttune(ctx, service)
+	case "use-charge":
+		handleUseCharge(ctx, service)
 	case "learn-spell":
 		handleLearnSpell(ctx, service)
 	case "prepare-spell":
 		handlePrepareSpell(ctx, service)
+	case "forget-spell":
+		handleForgetSpell(ctx, service)
+	case "swap-spell":
+		handleSwapSpell(ctx, service)
+	case "cast":
+		handleCast(ctx, service)
+	case "spellbook":
+		handleSpellbook(ctx, service)
 	case "delete":
 		handleDelete(ctx, service)
+	case "roll":
+		handleRoll()
+	case "attack":
+		handleAttack(ctx, service)
+	case "damage":
+		handleDamage(ctx, service)
+	case "heal":
+		handleHeal(ctx, service)
+	case "temp-hp":
+		handleTempHP(ctx, service)
+	case "death-save":
+		handleDeathSave(ctx, service)
+	case "short-rest":
+		handleShortRest(ctx, service)
+	case "long-rest":
+		handleLongRest(ctx, service)
+	case "apply-condition":
+		handleApplyCondition(ctx, service)
+	case "remove-condition":
+		handleRemoveCondition(ctx, service)
+	case "pass-time":
+		handlePassTime(ctx, service)
 	default:
 		usage()
 		os.Exit(1)
@@ -86,15 +188,22 @@ func handleCreate(ctx context.Context, service *application.CharacterService) {
 	name := createCmd.String("name", "", "Character Name")
 	race := createCmd.String("race", "Human", "Race")
 	class := createCmd.String("class", "Wizard", "Class")
+	subclass := createCmd.String("subclass", "", "Subclass (required once the class reaches its subclass level)")
 	background := createCmd.String("background", "Acolyte", "Background")
 	level := createCmd.Int("level", 1, "Initial Level (defaults to 1)")
-	str := createCmd.Int("str", 10, "Strength Score")
-	dex := createCmd.Int("dex", 10, "Dexterity Score")
-	con := createCmd.Int("con", 10, "Constitution Score")
-	intl := createCmd.Int("int", 10, "Intelligence Score")
-	wis := createCmd.Int("wis", 10, "Wisdom Score")
-	cha := createCmd.Int("cha", 10, "Charisma Score")
+	str := createCmd.Int("str", 0, "Strength Score")
+	dex := createCmd.Int("dex", 0, "Dexterity Score")
+	con := createCmd.Int("con", 0, "Constitution Score")
+	intl := createCmd.Int("int", 0, "Intelligence Score")
+	wis := createCmd.Int("wis", 0, "Wisdom Score")
+	cha := createCmd.Int("cha", 0, "Charisma Score")
+	method := createCmd.String("method", "standard", "Ability score method: standard, point-buy or rolled")
+	roll := createCmd.Bool("roll", false, "Roll 4d6 drop lowest and assign the results to STR, DEX, CON, INT, WIS, CHA in order")
+	seed := createCmd.Int64("seed", 0, "Seed for rolled ability scores (random if omitted with -roll)")
 	skills := createCmd.String("skills", "", "Comma-separated list of initial skill proficiencies (e.g., Arcana,History)")
+	startingGear := createCmd.String("starting-gear", "", "Start with the class's 'equipment' or its starting 'wealth' in gold")
+	rollWealth := createCmd.Bool("roll-wealth", false, "Roll starting wealth instead of taking the class average")
+	asi := createCmd.String("asi", "", "Ability Score Improvements or feats for each ASI level up to -level, separated by ';', e.g. STR+2;tough;resilient:CON")
 
 	createCmd.Parse(os.Args[2:])
 
@@ -105,6 +214,17 @@ func handleCreate(ctx context.Context, service *application.CharacterService) {
 
 	scores := map[string]int{"STR": *str, "DEX": *dex, "CON": *con, "INT": *intl, "WIS": *wis, "CHA": *cha}
 
+	if *roll {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		*method = "rolled"
+
+		rolled := domain.RollAbilityScores(*seed)
+		scores = domain.AssignInOrder(rolled)
+		fmt.Printf("Rolled 4d6 drop lowest (seed %d): %v\n", *seed, rolled)
+	}
+
 	var initialSkills []string
 	if *skills != "" {
 		for _, s := range strings.Split(*skills, ",") {
@@ -115,9 +235,18 @@ func handleCreate(ctx context.Context, service *application.CharacterService) {
 		}
 	}
 
+	improvements, err := parseImprovements(*asi)
+	if err != nil {
+		fmt.Printf("%v", err)
+		os.Exit(2)
+	}
+
 	req := application.CreateCharacterRequest{
-		Name: *name, Race: *race, Class: *class, Background: *background, Level: *level,
+		Name: *name, Race: *race, Class: *class, Subclass: *subclass, Background: *background, Level: *level,
 		ScoreAssignments: scores, InitialSkills: initialSkills,
+		AbilityScoreMethod: *method, Seed: *seed,
+		StartingGear: *startingGear, RollWealth: *rollWealth,
+		Improvements: improvements,
 	}
 
 	char, err := service.CreateCharacter(ctx, req)
@@ -149,6 +278,42 @@ func handleView(ctx context.Context, service *application.CharacterService) {
 	displayCharacterSheet(char)
 }
 
+func handleExplain(ctx context.Context, service *application.CharacterService) {
+	explainCmd := flag.NewFlagSet("explain", flag.ExitOnError)
+	name := explainCmd.String("name", "", "Character Name")
+	stat := explainCmd.String("stat", "ac", "Statistic to explain (e.g., ac, initiative, \"DEX save\", Stealth, \"main hand\")")
+	explainCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		explainCmd.PrintDefaults()
+		return
+	}
+
+	breakdowns, err := service.ExplainStat(ctx, *name, *stat)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	for _, b := range breakdowns {
+		fmt.Printf("%s: %d\n", b.Name, b.Total)
+		for i, part := range b.Parts {
+			if i == 0 {
+				fmt.Printf("  %4d  %s\n", part.Value, part.Source)
+			} else {
+				fmt.Printf("  %+4d  %s\n", part.Value, part.Source)
+			}
+		}
+		if len(b.Advantage) > 0 {
+			fmt.Printf("  Advantage from: %s\n", strings.Join(b.Advantage, ", "))
+		}
+		if len(b.Disadvantage) > 0 {
+			fmt.Printf("  Disadvantage from: %s\n", strings.Join(b.Disadvantage, ", "))
+		}
+	}
+}
+
 func handleList(ctx context.Context, service *application.CharacterService) {
 	flag.NewFlagSet("list", flag.ExitOnError).Parse(os.Args[2:])
 
@@ -172,6 +337,7 @@ func handleUpdateLevel(ctx context.Context, service *application.CharacterServic
 	updateCmd := flag.NewFlagSet("update-level", flag.ExitOnError)
 	name := updateCmd.String("name", "", "Character Name")
 	level := updateCmd.Int("level", 0, "New Level (1-20)")
+	subclass := updateCmd.String("subclass", "", "Subclass (required when reaching the subclass level)")
 	updateCmd.Parse(os.Args[2:])
 
 	if *name == "" || *level < 1 || *level > 20 {
@@ -180,7 +346,7 @@ func handleUpdateLevel(ctx context.Context, service *application.CharacterServic
 		return
 	}
 
-	err := service.UpdateCharacterLevel(ctx, *name, *level)
+	err := service.UpdateCharacterLevel(ctx, *name, *level, *subclass)
 	if err != nil {
 		fmt.Printf("Error updating character '%s': %v\n", *name, err)
 		return
@@ -188,6 +354,93 @@ func handleUpdateLevel(ctx context.Context, service *application.CharacterServic
 	fmt.Printf("Success! Character '%s' updated to Level %d.\n", *name, *level)
 }
 
+func handleLevelUp(ctx context.Context, service *application.CharacterService) {
+	levelUpCmd := flag.NewFlagSet("level-up", flag.ExitOnError)
+	name := levelUpCmd.String("name", "", "Character Name")
+	class := levelUpCmd.String("class", "", "Class to gain a level in (multiclasses if new)")
+	subclass := levelUpCmd.String("subclass", "", "Subclass (required when reaching the subclass level)")
+	asi := levelUpCmd.String("asi", "", "Ability Score Improvement, e.g. STR+2 or STR+1,DEX+1")
+	feat := levelUpCmd.String("feat", "", "Feat to take instead of an Ability Score Improvement")
+	featAbility := levelUpCmd.String("feat-ability", "", "Ability to increase for feats that offer a choice")
+	levelUpCmd.Parse(os.Args[2:])
+
+	if *name == "" || *class == "" {
+		fmt.Println("Error: Character name and class are required.")
+		levelUpCmd.PrintDefaults()
+		return
+	}
+
+	increases, err := parseAbilityIncreases(*asi)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	req := application.LevelUpRequest{
+		Name: *name, Class: *class, Subclass: *subclass,
+		AbilityIncreases: increases, Feat: *feat, FeatAbility: *featAbility,
+	}
+
+	err = service.LevelUp(ctx, req)
+	if err != nil {
+		fmt.Printf("Error levelling up character '%s': %v\n", *name, err)
+		return
+	}
+	fmt.Printf("Success! Character '%s' gained a level in %s.\n", *name, strings.ToLower(*class))
+}
+
+// parseAbilityIncreases parses "STR+2" or "STR+1,DEX+1".
+func parseAbilityIncreases(value string) (map[string]int, error) {
+	increases := make(map[string]int)
+	if value == "" {
+		return increases, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		ab, amountStr, ok := strings.Cut(strings.TrimSpace(part), "+")
+		if !ok {
+			return nil, fmt.Errorf("invalid ability increase '%s', expected e.g. STR+1", part)
+		}
+
+		amount, err := strconv.Atoi(amountStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ability increase '%s', expected e.g. STR+1", part)
+		}
+
+		increases[strings.ToUpper(strings.TrimSpace(ab))] += amount
+	}
+
+	return increases, nil
+}
+
+// parseImprovements parses ';'-separated ASI choices, each either ability
+// increases ("STR+2", "STR+1,DEX+1") or a feat with an optional ability
+// ("tough", "resilient:CON").
+func parseImprovements(value string) ([]application.ImprovementChoice, error) {
+	var choices []application.ImprovementChoice
+	if value == "" {
+		return choices, nil
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+
+		if strings.Contains(part, "+") {
+			increases, err := parseAbilityIncreases(part)
+			if err != nil {
+				return nil, err
+			}
+			choices = append(choices, application.ImprovementChoice{AbilityIncreases: increases})
+			continue
+		}
+
+		feat, ability, _ := strings.Cut(part, ":")
+		choices = append(choices, application.ImprovementChoice{Feat: strings.TrimSpace(feat), FeatAbility: strings.TrimSpace(ability)})
+	}
+
+	return choices, nil
+}
+
 func handleEquip(ctx context.Context, service *application.CharacterService) {
 	equipCmd := flag.NewFlagSet("equip", flag.ExitOnError)
 	name := equipCmd.String("name", "", "Character Name")
@@ -232,7 +485,7 @@ func handleEquip(ctx context.Context, service *application.CharacterService) {
 		return
 	}
 
-	err := service.EquipItem(ctx, *name, itemName, itemType, itemSlot)
+	warnings, err := service.EquipItem(ctx, *name, itemName, itemType, itemSlot)
 	if err != nil {
 		fmt.Printf("%v", err)
 		return
@@ -243,15 +496,400 @@ func handleEquip(ctx context.Context, service *application.CharacterService) {
 	} else {
 		fmt.Printf("Equipped %s %s", itemType, itemName)
 	}
+
+	for _, warning := range warnings {
+		fmt.Printf("\nWarning: %s", warning)
+	}
+}
+
+func handleUnequip(ctx context.Context, service *application.CharacterService) {
+	unequipCmd := flag.NewFlagSet("unequip", flag.ExitOnError)
+	name := unequipCmd.String("name", "", "Character Name")
+	slot := unequipCmd.String("slot", "", "Equipment slot to empty (main hand, off hand, armor or shield)")
+	unequipCmd.Parse(os.Args[2:])
+
+	if *name == "" || *slot == "" {
+		fmt.Println("Error: Character name and slot are required.")
+		unequipCmd.PrintDefaults()
+		return
+	}
+
+	_, item, err := service.Unequip(ctx, *name, *slot)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Unequipped %s from %s", item.Name, strings.ToLower(*slot))
+}
+
+func handleAddItem(ctx context.Context, service *application.CharacterService) {
+	addCmd := flag.NewFlagSet("add-item", flag.ExitOnError)
+	name := addCmd.String("name", "", "Character Name")
+	item := addCmd.String("item", "", "Item Name (e.g., Rope, hempen (50 feet))")
+	count := addCmd.Int("count", 1, "Number of items")
+	container := addCmd.String("container", "", "Carried container to store the items in (e.g., backpack)")
+	addCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and item name are required.")
+		addCmd.PrintDefaults()
+		return
+	}
+
+	_, stack, err := service.AddItem(ctx, *name, *item, *count, *container)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Added %d %s to the inventory (%d carried%s)", *count, stack.Item.Name, stack.Count, containerSuffix(stack.Container))
+}
+
+func handleRemoveItem(ctx context.Context, service *application.CharacterService) {
+	removeCmd := flag.NewFlagSet("remove-item", flag.ExitOnError)
+	name := removeCmd.String("name", "", "Character Name")
+	item := removeCmd.String("item", "", "Item Name")
+	count := removeCmd.Int("count", 1, "Number of items")
+	container := removeCmd.String("container", "", "Container the items are stored in")
+	removeCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and item name are required.")
+		removeCmd.PrintDefaults()
+		return
+	}
+
+	_, removed, err := service.RemoveItem(ctx, *name, *item, *count, *container)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Removed %d %s from the inventory", removed.Count, removed.Item.Name)
+}
+
+func handleTransferItem(ctx context.Context, service *application.CharacterService) {
+	transferCmd := flag.NewFlagSet("transfer-item", flag.ExitOnError)
+	from := transferCmd.String("from", "", "Character giving the items")
+	to := transferCmd.String("to", "", "Character receiving the items")
+	item := transferCmd.String("item", "", "Item Name")
+	count := transferCmd.Int("count", 1, "Number of items")
+	container := transferCmd.String("container", "", "Container the items are taken from")
+	transferCmd.Parse(os.Args[2:])
+
+	if *from == "" || *to == "" || *item == "" {
+		fmt.Println("Error: Both character names and the item name are required.")
+		transferCmd.PrintDefaults()
+		return
+	}
+
+	moved, err := service.TransferItem(ctx, *from, *to, *item, *count, *container)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("%s gave %d %s to %s", *from, moved.Count, moved.Item.Name, *to)
+}
+
+func handleBuy(ctx context.Context, service *application.CharacterService) {
+	buyCmd := flag.NewFlagSet("buy", flag.ExitOnError)
+	name := buyCmd.String("name", "", "Character Name")
+	item := buyCmd.String("item", "", "Item Name")
+	count := buyCmd.Int("count", 1, "Number of items")
+	container := buyCmd.String("container", "", "Carried container to store the items in (e.g., backpack)")
+	buyCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and item name are required.")
+		buyCmd.PrintDefaults()
+		return
+	}
+
+	char, trade, err := service.BuyItem(ctx, *name, *item, *count, *container)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Bought %d %s for %s (%s left)", trade.Item.Count, trade.Item.Item.Name, domain.FormatCost(trade.Price), char.Purse)
+}
+
+func handleSell(ctx context.Context, service *application.CharacterService) {
+	sellCmd := flag.NewFlagSet("sell", flag.ExitOnError)
+	name := sellCmd.String("name", "", "Character Name")
+	item := sellCmd.String("item", "", "Item Name")
+	count := sellCmd.Int("count", 1, "Number of items")
+	container := sellCmd.String("container", "", "Container the items are stored in")
+	sellCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and item name are required.")
+		sellCmd.PrintDefaults()
+		return
+	}
+
+	char, trade, err := service.SellItem(ctx, *name, *item, *count, *container)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Sold %d %s for %s (%s now)", trade.Item.Count, trade.Item.Item.Name, domain.FormatCost(trade.Price), char.Purse)
+}
+
+func handleCoins(ctx context.Context, service *application.CharacterService) {
+	coinsCmd := flag.NewFlagSet("coins", flag.ExitOnError)
+	name := coinsCmd.String("name", "", "Character Name")
+	add := coinsCmd.String("add", "", "Coins to add (e.g., 15 gp)")
+	spend := coinsCmd.String("spend", "", "Amount to spend, making change as needed (e.g., 3 sp)")
+	coinsCmd.Parse(os.Args[2:])
+
+	if *name == "" || (*add != "" && *spend != "") {
+		fmt.Println("Error: Character name is required, with at most one of -add or -spend.")
+		coinsCmd.PrintDefaults()
+		return
+	}
+
+	var char *domain.Character
+	var err error
+
+	switch {
+	case *add != "":
+		char, err = service.AddCoins(ctx, *name, *add)
+	case *spend != "":
+		char, err = service.SpendCoins(ctx, *name, *spend)
+	default:
+		char, err = service.GetCharacter(ctx, *name)
+	}
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Coins: %s (worth %g gp)", char.Purse, float64(char.Purse.Total())/float64(domain.CopperValue["gp"]))
+}
+
+func handleAddMagicItem(ctx context.Context, service *application.CharacterService) {
+	addCmd := flag.NewFlagSet("add-magic-item", flag.ExitOnError)
+	name := addCmd.String("name", "", "Character Name")
+	item := addCmd.String("item", "", "Magic Item Name (e.g., Ring of Protection, +1 Weapon)")
+	base := addCmd.String("base", "", "Weapon, armor or shield a magic weapon, armor or shield is made from (e.g., Longsword)")
+	addCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and magic item name are required.")
+		addCmd.PrintDefaults()
+		return
+	}
+
+	_, magicItem, err := service.AddMagicItem(ctx, *name, *item, *base)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Added %s (%s)", magicItem.Name, magicItem.Rarity)
+	if magicItem.RequiresAttunement {
+		fmt.Print(", which requires attunement")
+	}
+}
+
+func handleAttune(ctx context.Context, service *application.CharacterService) {
+	attuneCmd := flag.NewFlagSet("attune", flag.ExitOnError)
+	name := attuneCmd.String("name", "", "Character Name")
+	item := attuneCmd.String("item", "", "Magic Item Name")
+	end := attuneCmd.Bool("end", false, "End the attunement instead")
+	attuneCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and magic item name are required.")
+		attuneCmd.PrintDefaults()
+		return
+	}
+
+	char, err := service.AttuneItem(ctx, *name, *item, *end)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	verb := "Attuned to"
+	if *end {
+		verb = "Ended attunement to"
+	}
+	fmt.Printf("%s %s (%d/%d attuned)", verb, *item, char.AttunedCount(), domain.MaxAttunedItems)
+}
+
+func handleUseCharge(ctx context.Context, service *application.CharacterService) {
+	useCmd := flag.NewFlagSet("use-charge", flag.ExitOnError)
+	name := useCmd.String("name", "", "Character Name")
+	item := useCmd.String("item", "", "Magic Item Name")
+	count := useCmd.Int("count", 1, "Number of charges")
+	useCmd.Parse(os.Args[2:])
+
+	if *name == "" || *item == "" {
+		fmt.Println("Error: Character name and magic item name are required.")
+		useCmd.PrintDefaults()
+		return
+	}
+
+	magicItem, err := service.UseMagicItemCharges(ctx, *name, *item, *count)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Used %d charges of %s (%d/%d left)", *count, magicItem.Name, magicItem.Charges, magicItem.MaxCharges)
+}
+
+// formatMagicItem describes a magic item for the character sheet, e.g.
+// "ring of protection (rare, attuned)".
+func formatMagicItem(item domain.MagicItem) string {
+	details := []string{string(item.Rarity)}
+	switch {
+	case item.Attuned:
+		details = append(details, "attuned")
+	case item.RequiresAttunement:
+		details = append(details, "not attuned")
+	}
+	if item.MaxCharges > 0 {
+		details = append(details, fmt.Sprintf("%d/%d charges", item.Charges, item.MaxCharges))
+	}
+	return fmt.Sprintf("%s (%s)", item.Name, strings.Join(details, ", "))
+}
+
+func handleApplyCondition(ctx context.Context, service *application.CharacterService) {
+	applyCmd := flag.NewFlagSet("apply-condition", flag.ExitOnError)
+	name := applyCmd.String("name", "", "Character Name")
+	condition := applyCmd.String("condition", "", "Condition (e.g., poisoned, prone, exhaustion)")
+	source := applyCmd.String("source", "", "What caused the condition (e.g., giant spider)")
+	rounds := applyCmd.Int("rounds", 0, "Rounds the condition lasts (0 until removed)")
+	levels := applyCmd.Int("levels", 1, "Exhaustion levels to add")
+	applyCmd.Parse(os.Args[2:])
+
+	if *name == "" || *condition == "" {
+		fmt.Println("Error: Character name and condition are required.")
+		applyCmd.PrintDefaults()
+		return
+	}
+
+	char, err := service.ApplyCondition(ctx, *name, domain.Condition{Name: *condition, Source: *source, Rounds: *rounds, Level: *levels})
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("%s is now: %s", char.Name, formatConditions(char))
+	if char.Dead {
+		fmt.Printf("\n%s dies of exhaustion", char.Name)
+	}
+}
+
+func handleRemoveCondition(ctx context.Context, service *application.CharacterService) {
+	removeCmd := flag.NewFlagSet("remove-condition", flag.ExitOnError)
+	name := removeCmd.String("name", "", "Character Name")
+	condition := removeCmd.String("condition", "", "Condition to end (exhaustion goes down one level)")
+	removeCmd.Parse(os.Args[2:])
+
+	if *name == "" || *condition == "" {
+		fmt.Println("Error: Character name and condition are required.")
+		removeCmd.PrintDefaults()
+		return
+	}
+
+	char, err := service.RemoveCondition(ctx, *name, *condition)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("%s is now: %s", char.Name, formatConditions(char))
+}
+
+func handlePassTime(ctx context.Context, service *application.CharacterService) {
+	passCmd := flag.NewFlagSet("pass-time", flag.ExitOnError)
+	name := passCmd.String("name", "", "Character Name")
+	rounds := passCmd.Int("rounds", 1, "Rounds of 6 seconds to pass")
+	passCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		passCmd.PrintDefaults()
+		return
+	}
+
+	char, ended, err := service.PassTime(ctx, *name, *rounds)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	if len(ended) > 0 {
+		fmt.Printf("No longer %s\n", strings.Join(ended, ", "))
+	}
+	fmt.Printf("%s is now: %s", char.Name, formatConditions(char))
+}
+
+// formatConditions lists the character's conditions, or "no conditions".
+func formatConditions(char *domain.Character) string {
+	var conditions []string
+	for _, cond := range char.SortedConditions() {
+		conditions = append(conditions, cond.String())
+	}
+	if len(conditions) == 0 {
+		return "no conditions"
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// rollNotes lists proficiency and the roll's advantage or disadvantage, e.g.
+// " (proficient, disadvantage)".
+func rollNotes(b domain.Breakdown) string {
+	var notes []string
+	if b.Proficient {
+		notes = append(notes, "proficient")
+	}
+	switch {
+	case b.HasAdvantage():
+		notes = append(notes, "advantage")
+	case b.HasDisadvantage():
+		notes = append(notes, "disadvantage")
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, ", ") + ")"
+}
+
+// formatWeight writes pounds without float noise, e.g. 0.75 rather than 0.7500000000000001.
+func formatWeight(pounds float64) string {
+	return strconv.FormatFloat(math.Round(pounds*100)/100, 'f', -1, 64)
+}
+
+func containerSuffix(container string) string {
+	if container == "" {
+		return ""
+	}
+	return " in " + container
 }
 
 func displayCharacterSheet(char *domain.Character) {
 	fmt.Printf("Name: %s\n", char.Name)
 	fmt.Printf("Class: %s\n", strings.ToLower(char.Class))
+	if subclasses := char.SubclassNames(); len(subclasses) > 0 {
+		fmt.Printf("Subclass: %s\n", strings.Join(subclasses, ", "))
+	}
 	fmt.Printf("Race: %s\n", strings.ToLower(char.Race))
 	fmt.Printf("Background: %s\n", strings.ToLower(char.Background))
 	fmt.Printf("Level: %d\n", char.Level)
 
+	if char.IsMulticlassed() {
+		fmt.Printf("Classes: %s\n", char.ClassSummary())
+	}
+
 	fmt.Println("Ability scores:")
 	for _, ab := range []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"} {
 		score := char.AbilityScores[ab]
@@ -260,6 +898,12 @@ func displayCharacterSheet(char *domain.Character) {
 
 	fmt.Printf("Proficiency bonus: +%d\n", char.ProficiencyBonus)
 
+	fmt.Println("Saving throws:")
+	for _, ab := range []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"} {
+		save := char.SavingThrowBreakdown(ab)
+		fmt.Printf("  %s: %+d%s\n", ab, save.Total, rollNotes(save))
+	}
+
 	var proficiencies []string
 
 	for skill, proficient := range char.SkillProficiencies {
@@ -276,6 +920,43 @@ func displayCharacterSheet(char *domain.Character) {
 
 	fmt.Printf("Skill proficiencies: %s\n", strings.Join(proficiencies, ", "))
 
+	if len(char.ArmorProficiencies) > 0 {
+		fmt.Printf("Armor proficiencies: %s\n", char.ArmorProficiencies)
+	}
+	if len(char.WeaponProficiencies) > 0 {
+		fmt.Printf("Weapon proficiencies: %s\n", char.WeaponProficiencies)
+	}
+	if len(char.ToolProficiencies) > 0 {
+		fmt.Printf("Tool proficiencies: %s\n", char.ToolProficiencies)
+	}
+
+	if len(char.Feats) > 0 {
+		fmt.Printf("Feats: %s\n", strings.Join(char.SortedFeats(), ", "))
+	}
+
+	if features := char.FeatureList(); len(features) > 0 {
+		fmt.Println("Features:")
+		for _, feature := range features {
+			name := feature.Name
+			if char.IsMulticlassed() {
+				name = fmt.Sprintf("%s [%s]", feature.Name, feature.Class)
+			}
+
+			if feature.HasLimitedUses() {
+				fmt.Printf("  %s (%d/%d per %s rest)\n", name, feature.UsesRemaining, feature.MaxUses, feature.Recharge)
+			} else {
+				fmt.Printf("  %s\n", name)
+			}
+		}
+	}
+
+	if features := char.SubclassFeatures(); len(features) > 0 {
+		fmt.Println("Subclass features:")
+		for _, feature := range features {
+			fmt.Printf("  %s (%s %d)\n", feature.Name, feature.Subclass, feature.Level)
+		}
+	}
+
 	hasSpellSlots := false
 	for _, count := range char.MaxSpellSlots {
 		if count > 0 {
@@ -297,7 +978,7 @@ func displayCharacterSheet(char *domain.Character) {
 				if level == 0 {
 					fmt.Printf("  Level 0: %d\n", char.MaxSpellSlots[level])
 				} else {
-					fmt.Printf("  Level %d: %d\n", level, char.MaxSpellSlots[level])
+					fmt.Printf("  Level %d: %d/%d\n", level, char.RemainingSpellSlots(level), char.MaxSpellSlots[level])
 				}
 			}
 		}
@@ -314,6 +995,21 @@ func displayCharacterSheet(char *domain.Character) {
 		fmt.Printf("Spell attack bonus: %+d\n", char.SpellAttackBonus)
 	}
 
+	if entries := char.SpellbookEntries(); len(entries) > 0 {
+		fmt.Println("Spellbook:")
+		for _, entry := range entries {
+			if entry.CostGP > 0 {
+				fmt.Printf("  %s (level %d, copied from %s: %d gp, %d hours)\n", entry.Spell.Name, entry.Spell.Level, entry.Source, entry.CostGP, entry.Hours)
+			} else {
+				fmt.Printf("  %s (level %d)\n", entry.Spell.Name, entry.Spell.Level)
+			}
+		}
+	}
+
+	if char.ConcentratingOn != "" {
+		fmt.Printf("Concentrating on: %s\n", char.ConcentratingOn)
+	}
+
 	if char.EquippedWeaponMainHand.Name != "" {
 		fmt.Printf("Main hand: %s\n", char.EquippedWeaponMainHand.Name)
 	}
@@ -322,16 +1018,95 @@ func displayCharacterSheet(char *domain.Character) {
 		fmt.Printf("Off hand: %s\n", char.EquippedWeaponOffHand.Name)
 	}
 
+	for _, attack := range []domain.WeaponAttack{char.MainHandAttack(), char.OffHandAttack()} {
+		if attack.Weapon == "" {
+			continue
+		}
+
+		fmt.Printf("  %s attack: %+d to hit, %s", attack.Weapon, attack.AttackBonus, attack.DamageExpression())
+		if attack.TwoHanded {
+			fmt.Print(" (two-handed)")
+		}
+		switch {
+		case attack.ToHit.HasAdvantage():
+			fmt.Print(" (advantage)")
+		case attack.ToHit.HasDisadvantage():
+			fmt.Print(" (disadvantage)")
+		}
+		fmt.Println()
+	}
+
 	if char.EquippedArmor.Name != "" {
-		fmt.Printf("Armor: %s\n", char.EquippedArmor.Name)
+		fmt.Printf("Armor: %s", char.EquippedArmor.Name)
+		if char.EquippedArmor.StealthDisadvantage {
+			fmt.Print(" (disadvantage on Stealth)")
+		}
+		if char.ArmorTooHeavy() {
+			fmt.Printf(" (below its STR %d requirement)", char.EquippedArmor.StrengthRequirement)
+		}
+		if !char.IsProficientWithArmor(char.EquippedArmor) {
+			fmt.Print(" (not proficient)")
+		}
+		fmt.Println()
 	}
 
 	if char.EquippedShield.Name != "" {
-		fmt.Printf("Shield: %s\n", char.EquippedShield.Name)
+		fmt.Printf("Shield: %s", char.EquippedShield.Name)
+		if !char.IsProficientWithShield() {
+			fmt.Print(" (not proficient)")
+		}
+		fmt.Println()
+	}
+
+	if char.WearingNonProficientArmor() {
+		fmt.Println("  Disadvantage on STR and DEX checks, and no spellcasting")
+	}
+
+	fmt.Printf("Coins: %s\n", char.Purse)
+
+	if len(char.Inventory) > 0 {
+		fmt.Println("Inventory:")
+		for _, item := range char.Inventory {
+			fmt.Printf("  %d %s%s (%s lb.)\n", item.Count, item.Item.Name, containerSuffix(item.Container), formatWeight(item.Weight()))
+		}
 	}
 
+	if len(char.MagicItems) > 0 {
+		fmt.Printf("Magic items (%d/%d attuned):\n", char.AttunedCount(), domain.MaxAttunedItems)
+		for _, item := range char.MagicItems {
+			fmt.Printf("  %s\n", formatMagicItem(item))
+		}
+	}
+
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+	if len(char.Conditions) > 0 {
+		fmt.Printf("Conditions: %s\n", formatConditions(char))
+
+		var disadvantaged []string
+		for _, ab := range []string{"STR", "DEX", "CON", "INT", "WIS", "CHA"} {
+			if char.HasDisadvantageOnChecks(ab) {
+				disadvantaged = append(disadvantaged, ab)
+			}
+		}
+		if len(disadvantaged) > 0 {
+			fmt.Printf("  Disadvantage on %s checks\n", strings.Join(disadvantaged, ", "))
+		}
+	}
+	if char.CurrentHitPoints == 0 {
+		fmt.Printf("Status: %s\n", char.HealthStatus())
+		if char.IsDying() {
+			fmt.Printf("Death saves: %d successes, %d failures\n", char.DeathSaveSuccesses, char.DeathSaveFailures)
+		}
+	}
+	fmt.Printf("Hit dice: %s of %s\n", char.HitDiceRemaining(), char.HitDiceTotal())
 	fmt.Printf("Armor class: %d\n", char.ArmorClass)
 	fmt.Printf("Initiative bonus: %d\n", char.Initiative)
+	if char.Encumbrance != "" && char.Encumbrance != domain.Unencumbered {
+		fmt.Printf("Speed: %d ft. (%s)\n", char.Speed, char.Encumbrance)
+	} else {
+		fmt.Printf("Speed: %d ft.\n", char.Speed)
+	}
+	fmt.Printf("Carrying: %s/%s lb.\n", formatWeight(char.CarriedWeight()), formatWeight(char.CarryingCapacity()))
 	fmt.Printf("Passive perception: %d\n", char.PassivePerception)
 }
 
@@ -339,6 +1114,7 @@ func handleLearnSpell(ctx context.Context, service *application.CharacterService
 	learnCmd := flag.NewFlagSet("learn-spell", flag.ExitOnError)
 	name := learnCmd.String("name", "", "Character Name")
 	spell := learnCmd.String("spell", "", "Spell Name to learn (e.g., Fireball)")
+	class := learnCmd.String("class", "", "Class to learn the spell for (defaults to the spellcasting class)")
 	learnCmd.Parse(os.Args[2:])
 
 	if *name == "" || *spell == "" {
@@ -347,7 +1123,7 @@ func handleLearnSpell(ctx context.Context, service *application.CharacterService
 		return
 	}
 
-	err := service.LearnSpell(ctx, *name, *spell)
+	err := service.LearnSpell(ctx, *name, *spell, *class)
 	if err != nil {
 		fmt.Printf("%v", err)
 		return
@@ -360,6 +1136,7 @@ func handlePrepareSpell(ctx context.Context, service *application.CharacterServi
 	prepareCmd := flag.NewFlagSet("prepare-spell", flag.ExitOnError)
 	name := prepareCmd.String("name", "", "Character Name")
 	spell := prepareCmd.String("spell", "", "Spell Name to prepare (e.g., Magic Missile)")
+	class := prepareCmd.String("class", "", "Class to prepare the spell for (defaults to the first class with it on its list)")
 	prepareCmd.Parse(os.Args[2:])
 
 	if *name == "" || *spell == "" {
@@ -368,7 +1145,7 @@ func handlePrepareSpell(ctx context.Context, service *application.CharacterServi
 		return
 	}
 
-	err := service.PrepareSpell(ctx, *name, *spell)
+	err := service.PrepareSpell(ctx, *name, *spell, *class)
 	if err != nil {
 		fmt.Printf("%v", err)
 		return
@@ -377,6 +1154,138 @@ func handlePrepareSpell(ctx context.Context, service *application.CharacterServi
 	fmt.Printf("Prepared spell %s", *spell)
 }
 
+func handleForgetSpell(ctx context.Context, service *application.CharacterService) {
+	forgetCmd := flag.NewFlagSet("forget-spell", flag.ExitOnError)
+	name := forgetCmd.String("name", "", "Character Name")
+	spell := forgetCmd.String("spell", "", "Spell Name to forget")
+	forgetCmd.Parse(os.Args[2:])
+
+	if *name == "" || *spell == "" {
+		fmt.Println("Error: Character name and spell name are required.")
+		forgetCmd.PrintDefaults()
+		return
+	}
+
+	err := service.ForgetSpell(ctx, *name, *spell)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Forgot spell %s", *spell)
+}
+
+func handleSwapSpell(ctx context.Context, service *application.CharacterService) {
+	swapCmd := flag.NewFlagSet("swap-spell", flag.ExitOnError)
+	name := swapCmd.String("name", "", "Character Name")
+	oldSpell := swapCmd.String("old", "", "Known spell to replace")
+	newSpell := swapCmd.String("new", "", "Spell to learn in its place")
+	class := swapCmd.String("class", "", "Class the spell was learned for (defaults to the spellcasting class)")
+	swapCmd.Parse(os.Args[2:])
+
+	if *name == "" || *oldSpell == "" || *newSpell == "" {
+		fmt.Println("Error: Character name, old spell and new spell are required.")
+		swapCmd.PrintDefaults()
+		return
+	}
+
+	err := service.SwapSpell(ctx, *name, *oldSpell, *newSpell, *class)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Replaced %s with %s", *oldSpell, *newSpell)
+}
+
+func handleCast(ctx context.Context, service *application.CharacterService) {
+	castCmd := flag.NewFlagSet("cast", flag.ExitOnError)
+	name := castCmd.String("name", "", "Character Name")
+	spell := castCmd.String("spell", "", "Spell Name to cast (e.g., Cure Wounds)")
+	slot := castCmd.Int("slot", 0, "Spell slot level to use (defaults to the spell's level)")
+	ritual := castCmd.Bool("ritual", false, "Cast as a ritual: no slot is used but it takes 10 minutes longer")
+	castCmd.Parse(os.Args[2:])
+
+	if *name == "" || *spell == "" {
+		fmt.Println("Error: Character name and spell name are required.")
+		castCmd.PrintDefaults()
+		return
+	}
+
+	if *ritual && *slot != 0 {
+		fmt.Println("Error: A ritual doesn't use a spell slot.")
+		castCmd.PrintDefaults()
+		return
+	}
+
+	var char *domain.Character
+	var result *domain.SpellCast
+	var err error
+
+	if *ritual {
+		char, result, err = service.CastRitual(ctx, *name, *spell)
+	} else {
+		char, result, err = service.CastSpell(ctx, *name, *spell, *slot)
+	}
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	if result.Ritual {
+		fmt.Printf("Cast %s as a ritual (%s + %s)\n", result.Spell.Name, result.Spell.CastingTime, domain.RitualExtraTime)
+	} else if result.SlotLevel == 0 {
+		fmt.Printf("Cast cantrip %s\n", result.Spell.Name)
+	} else {
+		fmt.Printf("Cast %s with a level %d slot\n", result.Spell.Name, result.SlotLevel)
+		fmt.Printf("Level %d slots remaining: %d/%d\n", result.SlotLevel, char.RemainingSpellSlots(result.SlotLevel), char.MaxSpellSlots[result.SlotLevel])
+	}
+
+	if result.Spell.School != "" {
+		fmt.Printf("%s, range %s\n", result.Spell.School, result.Spell.Range)
+	}
+	if result.Spell.Description != "" {
+		fmt.Println(result.Spell.Description)
+	}
+	if result.SlotLevel > result.Spell.Level && result.Spell.HigherLevel != "" {
+		fmt.Println(result.Spell.HigherLevel)
+	}
+
+	if result.EndedConcentration != "" {
+		fmt.Printf("Stopped concentrating on %s\n", result.EndedConcentration)
+	}
+	if result.Spell.Concentration {
+		fmt.Printf("Concentrating on %s (up to %s)\n", result.Spell.Name, result.Spell.Duration)
+	}
+}
+
+func handleSpellbook(ctx context.Context, service *application.CharacterService) {
+	spellbookCmd := flag.NewFlagSet("spellbook", flag.ExitOnError)
+	name := spellbookCmd.String("name", "", "Character Name")
+	spell := spellbookCmd.String("spell", "", "Spell Name to add to the spellbook (e.g., Shield)")
+	copyFrom := spellbookCmd.String("copy-from", "", "Copy the spell from a scroll or another spellbook (costs gold and time) instead of using a free level-up spell")
+	spellbookCmd.Parse(os.Args[2:])
+
+	if *name == "" || *spell == "" {
+		fmt.Println("Error: Character name and spell name are required.")
+		spellbookCmd.PrintDefaults()
+		return
+	}
+
+	entry, err := service.AddToSpellbook(ctx, *name, *spell, *copyFrom)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	if entry.CostGP > 0 {
+		fmt.Printf("Copied %s from a %s into the spellbook (%d gp, %d hours)", entry.Spell.Name, entry.Source, entry.CostGP, entry.Hours)
+		return
+	}
+
+	fmt.Printf("Added %s to the spellbook", entry.Spell.Name)
+}
+
 func handleDelete(ctx context.Context, service *application.CharacterService) {
 	deleteCmd := flag.NewFlagSet("delete", flag.ExitOnError)
 	name := deleteCmd.String("name", "", "Character Name (required)")
@@ -396,3 +1305,250 @@ func handleDelete(ctx context.Context, service *application.CharacterService) {
 
 	fmt.Printf("deleted %s", *name)
 }
+
+func handleRoll() {
+	rollCmd := flag.NewFlagSet("roll", flag.ExitOnError)
+	expr := rollCmd.String("expr", "", "Dice expression (e.g., 2d6+3, 4d6kh3, 1d20adv, 1d8+1d6 fire)")
+	seed := rollCmd.Int64("seed", 0, "Seed for reproducible rolls (random if omitted)")
+	rollCmd.Parse(os.Args[2:])
+
+	if *expr == "" {
+		fmt.Println("Error: A dice expression is required.")
+		rollCmd.PrintDefaults()
+		return
+	}
+
+	roller := dice.NewRandomRoller()
+	if *seed != 0 {
+		roller = dice.NewSeededRoller(*seed)
+	}
+
+	result, err := roller.Roll(*expr)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Println(result)
+}
+
+func handleAttack(ctx context.Context, service *application.CharacterService) {
+	attackCmd := flag.NewFlagSet("attack", flag.ExitOnError)
+	name := attackCmd.String("name", "", "Character Name")
+	hand := attackCmd.String("hand", "main", "Hand holding the weapon: main or off")
+	attackCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		attackCmd.PrintDefaults()
+		return
+	}
+
+	result, err := service.Attack(ctx, *name, *hand)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Attack with %s (%s): %s\n", result.Attack.Weapon, result.Attack.Hand, result.ToHit)
+	switch {
+	case result.Critical:
+		fmt.Println("Critical hit!")
+	case result.Fumble:
+		fmt.Println("Natural 1, the attack misses.")
+	}
+	fmt.Printf("Damage: %s\n", result.Damage)
+}
+
+func formatHitPoints(char *domain.Character) string {
+	hp := fmt.Sprintf("%d/%d", char.CurrentHitPoints, char.MaxHitPoints)
+	if char.TemporaryHitPoints > 0 {
+		hp += fmt.Sprintf(" (+%d temporary)", char.TemporaryHitPoints)
+	}
+	return hp
+}
+
+func handleDamage(ctx context.Context, service *application.CharacterService) {
+	damageCmd := flag.NewFlagSet("damage", flag.ExitOnError)
+	name := damageCmd.String("name", "", "Character Name")
+	amount := damageCmd.Int("amount", 0, "Damage taken")
+	crit := damageCmd.Bool("crit", false, "The damage is from a critical hit (two failed death saves at 0 HP)")
+	rollConcentration := damageCmd.Bool("roll-concentration", false, "Roll the CON save to keep concentrating")
+	damageCmd.Parse(os.Args[2:])
+
+	if *name == "" || *amount <= 0 {
+		fmt.Println("Error: Character name and a positive amount are required.")
+		damageCmd.PrintDefaults()
+		return
+	}
+
+	char, result, err := service.DamageCharacter(ctx, application.DamageRequest{
+		Name: *name, Amount: *amount, Critical: *crit, RollConcentration: *rollConcentration,
+	})
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	if result.AbsorbedByTempHP > 0 {
+		fmt.Printf("%d damage absorbed by temporary hit points\n", result.AbsorbedByTempHP)
+	}
+
+	switch {
+	case result.InstantDeath:
+		fmt.Printf("%s takes %d damage and is killed outright\n", char.Name, *amount)
+	case result.KnockedOut:
+		fmt.Printf("%s takes %d damage and falls unconscious\n", char.Name, *amount)
+	case result.DeathSaveFailures > 0:
+		fmt.Printf("%s takes damage while dying: %d failed death save(s)\n", char.Name, result.DeathSaveFailures)
+		if char.Dead {
+			fmt.Printf("%s has died\n", char.Name)
+		}
+	default:
+		fmt.Printf("%s takes %d damage\n", char.Name, *amount)
+	}
+
+	switch {
+	case result.LostConcentration != "":
+		fmt.Printf("Concentration on %s ends\n", result.LostConcentration)
+	case result.ConcentrationSave != nil:
+		fmt.Printf("Concentration save (DC %d): %s\n", result.ConcentrationDC, result.ConcentrationSave)
+		if !result.KeptConcentration {
+			fmt.Println("Concentration lost")
+		}
+	case result.ConcentrationDC > 0:
+		fmt.Printf("Make a DC %d Constitution save to keep concentrating on %s\n", result.ConcentrationDC, char.ConcentratingOn)
+	}
+
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+}
+
+func handleHeal(ctx context.Context, service *application.CharacterService) {
+	healCmd := flag.NewFlagSet("heal", flag.ExitOnError)
+	name := healCmd.String("name", "", "Character Name")
+	amount := healCmd.Int("amount", 0, "Hit points restored")
+	healCmd.Parse(os.Args[2:])
+
+	if *name == "" || *amount <= 0 {
+		fmt.Println("Error: Character name and a positive amount are required.")
+		healCmd.PrintDefaults()
+		return
+	}
+
+	char, healed, err := service.HealCharacter(ctx, *name, *amount)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("%s regains %d hit points\n", char.Name, healed)
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+}
+
+func handleTempHP(ctx context.Context, service *application.CharacterService) {
+	tempCmd := flag.NewFlagSet("temp-hp", flag.ExitOnError)
+	name := tempCmd.String("name", "", "Character Name")
+	amount := tempCmd.Int("amount", 0, "Temporary hit points granted")
+	tempCmd.Parse(os.Args[2:])
+
+	if *name == "" || *amount <= 0 {
+		fmt.Println("Error: Character name and a positive amount are required.")
+		tempCmd.PrintDefaults()
+		return
+	}
+
+	char, granted, err := service.GrantTemporaryHitPoints(ctx, *name, *amount)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	if !granted {
+		fmt.Printf("%s already has %d temporary hit points, which don't stack\n", char.Name, char.TemporaryHitPoints)
+		return
+	}
+
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+}
+
+func handleDeathSave(ctx context.Context, service *application.CharacterService) {
+	saveCmd := flag.NewFlagSet("death-save", flag.ExitOnError)
+	name := saveCmd.String("name", "", "Character Name")
+	saveCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		saveCmd.PrintDefaults()
+		return
+	}
+
+	char, result, err := service.RollDeathSave(ctx, *name)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("Death save: rolled %d\n", result.Roll)
+	switch {
+	case result.Regained:
+		fmt.Printf("Natural 20! %s regains 1 hit point\n", char.Name)
+	case result.Stabilized:
+		fmt.Printf("%s is stable\n", char.Name)
+	case result.Died:
+		fmt.Printf("%s has died\n", char.Name)
+	default:
+		fmt.Printf("Death saves: %d successes, %d failures\n", char.DeathSaveSuccesses, char.DeathSaveFailures)
+	}
+}
+
+func handleShortRest(ctx context.Context, service *application.CharacterService) {
+	restCmd := flag.NewFlagSet("short-rest", flag.ExitOnError)
+	name := restCmd.String("name", "", "Character Name")
+	hitDice := restCmd.Int("hit-dice", 0, "Number of hit dice to spend, largest first")
+	restCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		restCmd.PrintDefaults()
+		return
+	}
+
+	char, result, err := service.ShortRest(ctx, *name, *hitDice)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	for _, roll := range result.Rolls {
+		fmt.Printf("Hit die: %s\n", roll)
+	}
+	if len(result.Rolls) > 0 {
+		fmt.Printf("%s regains %d hit points\n", char.Name, result.HitPointsRegained)
+	}
+
+	fmt.Printf("%s finishes a short rest\n", char.Name)
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+	fmt.Printf("Hit dice remaining: %s\n", char.HitDiceRemaining())
+}
+
+func handleLongRest(ctx context.Context, service *application.CharacterService) {
+	restCmd := flag.NewFlagSet("long-rest", flag.ExitOnError)
+	name := restCmd.String("name", "", "Character Name")
+	restCmd.Parse(os.Args[2:])
+
+	if *name == "" {
+		fmt.Println("Error: Character name is required.")
+		restCmd.PrintDefaults()
+		return
+	}
+
+	char, err := service.LongRest(ctx, *name)
+	if err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+
+	fmt.Printf("%s finishes a long rest\n", char.Name)
+	fmt.Printf("Hit points: %s\n", formatHitPoints(char))
+	fmt.Printf("Hit dice remaining: %s\n", char.HitDiceRemaining())
+}
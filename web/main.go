@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"dnd-char-generator/internal/application"
+	"dnd-char-generator/internal/dice"
 	"dnd-char-generator/internal/infrastructure"
 	"dnd-char-generator/internal/infrastructure/dndapi"
 	"dnd-char-generator/internal/infrastructure/persistence"
@@ -47,14 +52,26 @@ func init() {
 }
 
 func initApp() (*application.CharacterService, error) {
-	allSpells, allWeapons, allArmors, allShields, err := infrastructure.LoadData("5e-SRD-Equipment.csv", "5e-SRD-Spells.csv")
+	allSpells, allWeapons, allArmors, allShields, allGear, classFeatures, err := infrastructure.LoadData(
+		"5e-SRD-Equipment.csv", "5e-SRD-Spells.csv", "5e-SRD-Spell-Compendium.json", "5e-SRD-Class-Features.csv")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load static SRD data: %w", err)
 	}
 
 	repo := persistence.NewFileRepository("characters.json")
 	apiClient := dndapi.NewClient()
-	service := application.NewCharacterService(repo, apiClient, allSpells, allWeapons, allArmors, allShields)
+	service := application.NewCharacterService(repo, apiClient, allSpells, allWeapons, allArmors, allShields, allGear, classFeatures)
+	service.Rules = infrastructure.LoadRules()
+
+	magicItems, err := infrastructure.LoadMagicItems("5e-SRD-Magic-Items.json")
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Println("Warning: Magic items file '5e-SRD-Magic-Items.json' not found, no magic items available")
+	case err != nil:
+		return nil, fmt.Errorf("failed to load magic items: %w", err)
+	default:
+		service.MagicItems = magicItems
+	}
 
 	return service, nil
 }
@@ -75,6 +92,7 @@ func main() {
 
 	mux.HandleFunc("GET /characters", app.listCharactersHandler)
 	mux.HandleFunc("GET /characters/{name}", app.viewCharacterHandler)
+	mux.HandleFunc("GET /roll", app.rollHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -144,3 +162,37 @@ func (app *Server) viewCharacterHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "Failed to render character sheet template", http.StatusInternalServerError)
 	}
 }
+
+func (app *Server) rollHandler(w http.ResponseWriter, r *http.Request) {
+	expr := r.URL.Query().Get("expr")
+	if expr == "" {
+		http.Error(w, "Query parameter 'expr' is required.", http.StatusBadRequest)
+		return
+	}
+
+	roller := dice.NewRandomRoller()
+	if seedStr := r.URL.Query().Get("seed"); seedStr != "" {
+		seed, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			http.Error(w, "Query parameter 'seed' must be an integer.", http.StatusBadRequest)
+			return
+		}
+		roller = dice.NewSeededRoller(seed)
+	}
+
+	result, err := roller.Roll(expr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := struct {
+		dice.Result
+		Breakdown string
+	}{Result: result, Breakdown: result.String()}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("ERROR: Failed to encode roll result: %v", err)
+	}
+}